@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backend registers the language backends connectors can be
+// generated for. The core pipeline -- schema loading, synthesis, and
+// validation -- is language-agnostic; a backend turns the validated schema
+// and operations into sources for one target language.
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// A Backend generates a connector's sources for one target language.
+type Backend interface {
+	// Name is the identifier the backend is selected with.
+	Name() string
+
+	// Generate writes the connector's sources.
+	Generate(s *schema.Schema, operations []*schema.Operation) error
+}
+
+// factories holds one constructor per registered language.
+var factories = map[string]func() Backend{}
+
+// Register makes a backend selectable by name. Backends register
+// themselves from their package's init function.
+func Register(name string, factory func() Backend) {
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("backend %q registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// New returns a fresh, unconfigured backend for the named language.
+func New(name string) (Backend, error) {
+	factory := factories[name]
+	if factory == nil {
+		return nil, fmt.Errorf("unknown language %q: available languages are %s",
+			name, strings.Join(Names(), ", "))
+	}
+	return factory(), nil
+}
+
+// Names lists the registered languages, sorted.
+func Names() []string {
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Configuration is layered: built-in defaults, then a config file, then
+// DATACONNECT_CODEGEN_* environment variables, then command-line flags, with
+// later layers overriding earlier ones. Every layer sets the same named
+// options that the flags declare.
+
+// resolveConfig fills in flags that were not set on the command line from the
+// environment and then from the config file named by the -config flag, and
+// returns where each flag's effective value came from, keyed by flag name.
+func resolveConfig(flags *flag.FlagSet) (map[string]string, error) {
+	sources := map[string]string{}
+	flags.Visit(func(f *flag.Flag) {
+		sources[f.Name] = "flag"
+	})
+	var err error
+	flags.VisitAll(func(f *flag.Flag) {
+		if err != nil || sources[f.Name] != "" {
+			return
+		}
+		name := envName(f.Name)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if setErr := flags.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("%s: %w", name, setErr)
+			return
+		}
+		sources[f.Name] = "env " + name
+	})
+	if err != nil {
+		return nil, err
+	}
+	configFile := flags.Lookup("config").Value.String()
+	if configFile == "" {
+		if dir, err := os.Getwd(); err == nil {
+			configFile = discoverConfigFile(dir)
+		}
+	}
+	if configFile != "" {
+		if err := applyConfigFile(flags, configFile, sources); err != nil {
+			return nil, err
+		}
+	}
+	flags.VisitAll(func(f *flag.Flag) {
+		if sources[f.Name] == "" {
+			sources[f.Name] = "default"
+		}
+	})
+	return sources, nil
+}
+
+// applyConfigFile sets flags from a config file for every flag not already
+// set by a higher-precedence layer. A .yaml or .yml file is parsed as YAML;
+// anything else is the plain format of one "name = value" per line, with #
+// starting a comment.
+func applyConfigFile(flags *flag.FlagSet, path string, sources map[string]string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var entries []configEntry
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		entries, err = parseYAMLConfig(path, string(contents))
+	} else {
+		entries, err = parsePlainConfig(path, string(contents))
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if flags.Lookup(entry.name) == nil {
+			return fmt.Errorf("%s:%d: unknown option %q", path, entry.line, entry.name)
+		}
+		if sources[entry.name] != "" {
+			continue
+		}
+		if err := flags.Set(entry.name, entry.value); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, entry.line, err)
+		}
+		sources[entry.name] = "config " + path
+	}
+	return nil
+}
+
+// configEntry is one option read from a config file, with the line it came
+// from for error reporting.
+type configEntry struct {
+	line  int
+	name  string
+	value string
+}
+
+// parsePlainConfig reads the "name = value" per line format.
+func parsePlainConfig(path, contents string) ([]configEntry, error) {
+	var entries []configEntry
+	for i, line := range strings.Split(contents, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected 'name = value', got %q", path, i+1, line)
+		}
+		entries = append(entries, configEntry{
+			line:  i + 1,
+			name:  strings.TrimSpace(name),
+			value: strings.TrimSpace(value),
+		})
+	}
+	return entries, nil
+}
+
+// parseYAMLConfig reads the YAML subset a flat tool configuration needs:
+// top-level "name: value" entries with # comments, optionally quoted
+// scalars, inline lists, and one level of nested mapping. A nested mapping
+// or inline list flattens to the comma-separated form the corresponding
+// flag takes, so e.g. a scalar_mappings block of "UUID: java.util.UUID"
+// lines becomes UUID=java.util.UUID,... for -scalar_mappings.
+func parseYAMLConfig(path, contents string) ([]configEntry, error) {
+	var entries []configEntry
+	lines := strings.Split(contents, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := yamlStripComment(lines[i])
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line != strings.TrimLeft(line, " \t") {
+			return nil, fmt.Errorf("%s:%d: unexpected indentation", path, i+1)
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected 'name: value', got %q", path, i+1, strings.TrimSpace(line))
+		}
+		entry := configEntry{line: i + 1, name: strings.TrimSpace(name)}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			// A nested mapping: the following indented lines become
+			// comma-separated key=value pairs.
+			var pairs []string
+			for i+1 < len(lines) {
+				next := yamlStripComment(lines[i+1])
+				if strings.TrimSpace(next) == "" {
+					break
+				}
+				if next == strings.TrimLeft(next, " \t") {
+					break
+				}
+				subName, subValue, found := strings.Cut(strings.TrimSpace(next), ":")
+				if !found || strings.TrimSpace(subValue) == "" {
+					return nil, fmt.Errorf("%s:%d: expected an indented 'key: value' entry", path, i+2)
+				}
+				pairs = append(pairs, strings.TrimSpace(subName)+"="+yamlScalar(strings.TrimSpace(subValue)))
+				i++
+			}
+			if len(pairs) == 0 {
+				return nil, fmt.Errorf("%s:%d: option %q has no value", path, entry.line, entry.name)
+			}
+			entry.value = strings.Join(pairs, ",")
+		} else {
+			entry.value = yamlValue(value)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// yamlStripComment drops a trailing # comment. Quoted values containing #
+// must put the quote before the #; scanning quote state is deliberately not
+// attempted for a config this small.
+func yamlStripComment(line string) string {
+	if comment := strings.Index(line, "#"); comment >= 0 {
+		return line[:comment]
+	}
+	return line
+}
+
+// yamlValue resolves a scalar or inline list to the string the flag is set
+// to; list elements join with commas.
+func yamlValue(value string) string {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		elements := strings.Split(strings.Trim(value, "[]"), ",")
+		for i, element := range elements {
+			elements[i] = yamlScalar(strings.TrimSpace(element))
+		}
+		return strings.Join(elements, ",")
+	}
+	return yamlScalar(value)
+}
+
+// yamlScalar strips one level of matched quotes.
+func yamlScalar(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// configFileNames are the project-level config files discovered when
+// -config is not set, in the order they are preferred within a directory.
+var configFileNames = []string{".dataconnectrc", "dataconnect-codegen.yaml", "dataconnect-codegen.yml"}
+
+// discoverConfigFile walks from dir up to the filesystem root and returns
+// the first config file found, or "" when there is none. This lets the tool
+// be invoked from any subdirectory of a project and still pick up the
+// project's settings.
+func discoverConfigFile(dir string) string {
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// envName returns the environment variable consulted for a flag, e.g.
+// DATACONNECT_CODEGEN_KOTLIN_PACKAGE for -kotlin_package.
+func envName(flagName string) string {
+	return "DATACONNECT_CODEGEN_" + strings.ToUpper(flagName)
+}
+
+// printConfig writes the effective merged configuration, one flag per line,
+// with the layer each value came from.
+func printConfig(w io.Writer, flags *flag.FlagSet, sources map[string]string) {
+	var names []string
+	flags.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s = %s  # %s\n", name, flags.Lookup(name).Value, sources[name])
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The Firebase CLI already describes a Data Connect project: dataconnect.yaml
+// names the schema source and the connector directories, and each connector's
+// connector.yaml carries its connectorId and optional generated-SDK settings.
+// -dataconnect_yaml derives the codegen's input flags from those files so the
+// paths are not duplicated into Gradle.
+
+// firebaseConnector is one connector read from its connector.yaml.
+type firebaseConnector struct {
+	id            string
+	dir           string
+	kotlinPackage string
+}
+
+// firebaseProject is what the codegen needs from a dataconnect.yaml and the
+// connector.yaml files it points at. All paths are resolved relative to the
+// dataconnect.yaml's directory.
+type firebaseProject struct {
+	schemaDir  string
+	connectors []firebaseConnector
+}
+
+// loadFirebaseProject reads path as a Firebase CLI dataconnect.yaml along
+// with each listed connector's connector.yaml.
+func loadFirebaseProject(path string) (*firebaseProject, error) {
+	tree, err := readYAMLTree(path)
+	if err != nil {
+		return nil, err
+	}
+	base := filepath.Dir(path)
+	project := &firebaseProject{schemaDir: filepath.Join(base, "schema")}
+	if schema, ok := tree["schema"].(map[string]any); ok {
+		if source, ok := schema["source"].(string); ok && source != "" {
+			project.schemaDir = filepath.Join(base, filepath.FromSlash(source))
+		}
+	}
+	dirs, ok := tree["connectorDirs"].([]string)
+	if !ok || len(dirs) == 0 {
+		return nil, fmt.Errorf("%s: no connectorDirs listed", path)
+	}
+	for _, dir := range dirs {
+		connector, err := loadFirebaseConnector(filepath.Join(base, filepath.FromSlash(dir)))
+		if err != nil {
+			return nil, err
+		}
+		project.connectors = append(project.connectors, connector)
+	}
+	return project, nil
+}
+
+// loadFirebaseConnector reads the connector.yaml of one connector directory.
+func loadFirebaseConnector(dir string) (firebaseConnector, error) {
+	path := filepath.Join(dir, "connector.yaml")
+	tree, err := readYAMLTree(path)
+	if err != nil {
+		return firebaseConnector{}, err
+	}
+	connector := firebaseConnector{dir: dir}
+	connector.id, _ = tree["connectorId"].(string)
+	if connector.id == "" {
+		return firebaseConnector{}, fmt.Errorf("%s: missing connectorId", path)
+	}
+	if generate, ok := tree["generate"].(map[string]any); ok {
+		if kotlinSdk, ok := generate["kotlinSdk"].(map[string]any); ok {
+			connector.kotlinPackage, _ = kotlinSdk["package"].(string)
+		}
+	}
+	return connector, nil
+}
+
+// flagValues returns the flag settings the project derives, keyed by flag
+// name. kotlin_package is only derived when every connector declares one.
+func (p *firebaseProject) flagValues() map[string]string {
+	dirs := make([]string, 0, len(p.connectors))
+	ids := make([]string, 0, len(p.connectors))
+	packages := make([]string, 0, len(p.connectors))
+	for _, connector := range p.connectors {
+		dirs = append(dirs, connector.dir)
+		ids = append(ids, connector.id)
+		packages = append(packages, connector.kotlinPackage)
+	}
+	values := map[string]string{
+		"schema_dir":    p.schemaDir,
+		"connector_dir": strings.Join(dirs, ","),
+		"connector":     strings.Join(ids, ","),
+	}
+	for _, pkg := range packages {
+		if pkg == "" {
+			return values
+		}
+	}
+	values["kotlin_package"] = strings.Join(packages, ",")
+	return values
+}
+
+// readYAMLTree parses the YAML subset the CLI config files use: nested
+// mappings by indentation, "- " list items, inline lists, and scalar values.
+func readYAMLTree(path string) (map[string]any, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parser := &yamlTreeParser{path: path}
+	for i, line := range strings.Split(string(contents), "\n") {
+		text := yamlStripComment(line)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		parser.lines = append(parser.lines, yamlTreeLine{
+			number: i + 1,
+			indent: len(text) - len(trimmed),
+			text:   strings.TrimSpace(trimmed),
+		})
+	}
+	tree, err := parser.mapping(0)
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.lines) {
+		return nil, fmt.Errorf("%s:%d: unexpected indentation", path, parser.lines[parser.pos].number)
+	}
+	return tree, nil
+}
+
+type yamlTreeLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlTreeParser struct {
+	path  string
+	lines []yamlTreeLine
+	pos   int
+}
+
+// mapping parses the "name: value" entries at the given indentation,
+// descending into nested mappings and lists.
+func (p *yamlTreeParser) mapping(indent int) (map[string]any, error) {
+	tree := map[string]any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent {
+			break
+		}
+		name, value, found := strings.Cut(line.text, ":")
+		if !found || strings.HasPrefix(line.text, "-") {
+			return nil, fmt.Errorf("%s:%d: expected 'name: value', got %q", p.path, line.number, line.text)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		p.pos++
+		switch {
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			var list []string
+			for _, element := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if element = strings.TrimSpace(element); element != "" {
+					list = append(list, yamlScalar(element))
+				}
+			}
+			tree[name] = list
+		case value != "":
+			tree[name] = yamlScalar(value)
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			if strings.HasPrefix(p.lines[p.pos].text, "-") {
+				tree[name] = p.list(p.lines[p.pos].indent)
+			} else {
+				nested, err := p.mapping(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				tree[name] = nested
+			}
+		default:
+			tree[name] = ""
+		}
+	}
+	return tree, nil
+}
+
+// list parses the "- item" entries at the given indentation.
+func (p *yamlTreeParser) list(indent int) []string {
+	var list []string
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !strings.HasPrefix(line.text, "-") {
+			break
+		}
+		list = append(list, yamlScalar(strings.TrimSpace(strings.TrimPrefix(line.text, "-"))))
+		p.pos++
+	}
+	return list
+}
@@ -0,0 +1,10 @@
+module github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen
+
+go 1.21
+
+require (
+	github.com/gertd/go-pluralize v0.2.1
+	github.com/vektah/gqlparser/v2 v2.5.11
+)
+
+require github.com/agnivade/levenshtein v1.1.1 // indirect
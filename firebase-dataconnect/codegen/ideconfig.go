@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// writeIDEConfig writes an SDL dump of the synthesized schema and a
+// graphql.config.yml pointing IDE GraphQL plugins at it. Editors validating
+// operations against the raw schema sources reject the fields that only
+// exist after synthesis (movie_insert, movies, Movie_Key), so the dump gives
+// them the schema the generator actually validates against.
+func writeIDEConfig(dir string, s *schema.Schema, connectorDirs []string) error {
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return err
+	}
+	sdlPath := filepath.Join(dir, "schema.gql")
+	if err := os.WriteFile(sdlPath, []byte(s.SDL()), 0o666); err != nil {
+		return err
+	}
+	var config strings.Builder
+	config.WriteString("# Generated by the Firebase Data Connect Kotlin code generator. Do not edit.\n")
+	config.WriteString("schema: schema.gql\n")
+	config.WriteString("documents:\n")
+	for _, connectorDir := range connectorDirs {
+		pattern := filepath.ToSlash(filepath.Join(connectorDir, "**", "*.gql"))
+		fmt.Fprintf(&config, "  - %q\n", pattern)
+	}
+	return os.WriteFile(filepath.Join(dir, "graphql.config.yml"), []byte(config.String()), 0o666)
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// authRequiringLevels are the @auth levels that presuppose a signed-in
+// user. PUBLIC needs none and NO_ACCESS rejects everyone, signed in or not.
+var authRequiringLevels = map[string]bool{
+	"USER":                true,
+	"USER_ANON":           true,
+	"USER_EMAIL_VERIFIED": true,
+}
+
+// renderAuthMetadata writes the operation's auth metadata, and with
+// AuthGuard a checkAuth precondition, so callers can fail with a pointed
+// message before the request instead of decoding an opaque server error
+// after it.
+func renderAuthMetadata(w *writer, m *operationModel) {
+	requires := authRequiringLevels[m.AuthLevel]
+	w.line("")
+	w.line("/** Whether executing this operation requires a signed-in user. */")
+	w.line("public const val requiresAuth: Boolean = %t", requires)
+	w.line("")
+	w.line("/** The operation's declared @auth level, empty when it declares none. */")
+	w.line("public const val authLevel: String = %q", m.AuthLevel)
+	if !m.AuthGuard {
+		return
+	}
+	w.line("")
+	w.line("/** Throws when the operation needs a signed-in user and [currentUserId] is null. */")
+	w.line("public fun checkAuth(currentUserId: String?) {")
+	w.in()
+	w.line("check(!requiresAuth || currentUserId != null) {")
+	w.in()
+	w.line(`"operation %s requires a signed-in user (auth level %s), but no user is signed in"`,
+		m.Name, m.AuthLevel)
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
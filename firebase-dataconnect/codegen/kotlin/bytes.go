@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// isByteArray reports whether a field's Kotlin type is ByteArray, nullable
+// or not. Such fields need array-content semantics where data classes
+// default to reference semantics.
+func isByteArray(kotlinType string) bool {
+	return strings.TrimSuffix(kotlinType, "?") == "ByteArray"
+}
+
+// usesBytes reports whether any table carries a Bytes field, which is when
+// the generated sources reference the base64 serializer.
+func usesBytes(s *schema.Schema) bool {
+	for _, table := range s.Tables {
+		for _, field := range table.Fields {
+			if field.Type.Name() == "Bytes" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderBytesSerializer writes the serializer carrying the Bytes scalar as
+// base64 text, one copy per generated package.
+func renderBytesSerializer(w *writer, kotlinPackage string) {
+	w.line("package %s", kotlinPackage)
+	w.line("")
+	w.line("/** Serializes [ByteArray] as the base64 text the Bytes scalar travels as. */")
+	w.line("public object ByteArrayBase64Serializer : kotlinx.serialization.KSerializer<ByteArray> {")
+	w.in()
+	w.line("override val descriptor: kotlinx.serialization.descriptors.SerialDescriptor =")
+	w.in()
+	w.line("kotlinx.serialization.descriptors.PrimitiveSerialDescriptor(")
+	w.in()
+	w.line(`"Bytes",`)
+	w.line("kotlinx.serialization.descriptors.PrimitiveKind.STRING,")
+	w.out()
+	w.line(")")
+	w.out()
+	w.line("")
+	w.line("override fun serialize(encoder: kotlinx.serialization.encoding.Encoder, value: ByteArray) {")
+	w.in()
+	w.line("encoder.encodeString(java.util.Base64.getEncoder().encodeToString(value))")
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("override fun deserialize(decoder: kotlinx.serialization.encoding.Decoder): ByteArray =")
+	w.in()
+	w.line("java.util.Base64.getDecoder().decode(decoder.decodeString())")
+	w.out()
+	w.out()
+	w.line("}")
+}
+
+// renderByteArrayEquality overrides equals and hashCode for a class with
+// ByteArray fields: the data-class defaults compare arrays by reference,
+// so two decodes of the same response would not be equal.
+func renderByteArrayEquality(w *writer, c *classModel) {
+	w.line("override fun equals(other: Any?): Boolean {")
+	w.in()
+	w.line("if (this === other) return true")
+	w.line("if (other !is %s) return false", c.Name)
+	for _, field := range c.Fields {
+		name := field.property()
+		if isByteArray(field.Type) {
+			w.line("if (!(%s contentEquals other.%s)) return false", name, name)
+		} else {
+			w.line("if (%s != other.%s) return false", name, name)
+		}
+	}
+	w.line("return true")
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("override fun hashCode(): Int {")
+	w.in()
+	w.line("var result = 0")
+	for _, field := range c.Fields {
+		name := field.property()
+		switch {
+		case isByteArray(field.Type) && strings.HasSuffix(field.Type, "?"):
+			w.line("result = 31 * result + (%s?.contentHashCode() ?: 0)", name)
+		case isByteArray(field.Type):
+			w.line("result = 31 * result + %s.contentHashCode()", name)
+		default:
+			w.line("result = 31 * result + %s.hashCode()", name)
+		}
+	}
+	w.line("return result")
+	w.out()
+	w.line("}")
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// generateConnectorClass writes <Connector>Connector.kt, a class exposing
+// one typed handle per operation so call sites read like the official
+// generated SDKs: connector.getPost.execute(variables). Like the gRPC
+// stubs, the class binds no transport of its own -- the caller supplies a
+// single executor lambda and every handle routes through it, receiving the
+// operation's name, text, and kotlinx-serialized variables.
+func (g *Generator) generateConnectorClass(models []*operationModel) error {
+	connectorClass := upperCamel(g.Connector) + "Connector"
+	return g.writeFile(connectorClass+".kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		w.line("")
+		w.line("/** Typed entry point aggregating every operation of the %s connector. */", g.Connector)
+		w.line("public class %s(", connectorClass)
+		w.in()
+		w.line("private val executor: suspend (operationName: String, operationSource: String, variablesJson: String?) -> String,")
+		w.out()
+		w.line(") {")
+		w.in()
+		for _, model := range models {
+			operation := g.qualifiedOperation(model)
+			w.line("")
+			w.line("/** Executes the %s %s. */", model.Name, model.Kind)
+			w.line("public val %s: %sHandle = %sHandle()", lowerFirst(model.ClassName), model.ClassName, model.ClassName)
+			w.line("")
+			w.line("public inner class %sHandle internal constructor() {", model.ClassName)
+			w.in()
+			// The one-argument encodeToString/decodeFromString overloads are
+			// extension functions, which Kotlin cannot resolve through a fully
+			// qualified receiver; generated code carries no imports, so the
+			// member overloads taking an explicit serializer are used instead.
+			if model.Variables != nil {
+				w.line("public suspend fun execute(variables: %s.Variables): %s.Data {", operation, operation)
+				w.in()
+				w.line("val variablesJson = kotlinx.serialization.json.Json.encodeToString(")
+				w.in()
+				w.line("kotlinx.serialization.serializer<%s.Variables>(), variables)", operation)
+				w.out()
+				w.line("val response = executor(%s.operationName, %s.operationSource, variablesJson)", operation, operation)
+			} else {
+				w.line("public suspend fun execute(): %s.Data {", operation)
+				w.in()
+				w.line("val response = executor(%s.operationName, %s.operationSource, null)", operation, operation)
+			}
+			w.line("return kotlinx.serialization.json.Json.decodeFromString(")
+			w.in()
+			w.line("kotlinx.serialization.serializer<%s.Data>(), response)", operation)
+			w.out()
+			w.out()
+			w.line("}")
+			w.out()
+			w.line("}")
+		}
+		w.out()
+		w.line("}")
+	})
+}
+
+// qualifiedOperation returns the name the connector class refers to an
+// operation object by; operations sharded into a subpackage need their
+// package spelled out.
+func (g *Generator) qualifiedOperation(m *operationModel) string {
+	if m.Package != g.Package {
+		return m.Package + "." + m.ClassName
+	}
+	return m.ClassName
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// generateOperationConstants writes <Connector>Operations.kt, an object
+// holding one name constant per operation, so callers referring to
+// operations by name (logging, analytics, test fakes) need not repeat
+// string literals.
+func (g *Generator) generateOperationConstants(models []*operationModel) error {
+	objectName := upperCamel(g.Connector) + "Operations"
+	return g.writeFile(objectName+".kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		w.line("")
+		w.line("/** The operation names of the %s connector. */", g.Connector)
+		w.line("public object %s {", objectName)
+		w.in()
+		for _, model := range models {
+			w.line("public const val %s: String = %q", screamingSnake(model.Name), model.Name)
+		}
+		w.out()
+		w.line("}")
+	})
+}
+
+// upperCamel converts a connector name such as "my-connector" to
+// "MyConnector".
+func upperCamel(name string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			b.WriteRune(unicode.ToUpper(r))
+			upperNext = false
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// screamingSnake converts an operation name such as "ListMovies" to
+// "LIST_MOVIES".
+func screamingSnake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
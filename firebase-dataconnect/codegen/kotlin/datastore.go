@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// protoScalarTypes maps GraphQL scalars to proto3 field types. Custom
+// scalars travel in their serialized string form.
+var protoScalarTypes = map[string]string{
+	"String":  "string",
+	"ID":      "string",
+	"Int":     "int32",
+	"Float":   "double",
+	"Boolean": "bool",
+	"Int64":   "int64",
+}
+
+// generateDataStoreProto writes a proto3 schema for Proto DataStore with one
+// message per @table type, for apps that persist Data Connect rows locally,
+// plus the Kotlin converters between the response classes and the messages.
+func (g *Generator) generateDataStoreProto(s *schema.Schema, models []*operationModel) error {
+	if err := os.MkdirAll(g.DestDir, 0o777); err != nil {
+		return err
+	}
+	g.countFile()
+	err := streamFile(filepath.Join(g.DestDir, g.Connector+".proto"), func(w *writer) {
+		w.line("// Generated by the Firebase Data Connect Kotlin code generator. Do not edit.")
+		if g.InputChecksum != "" {
+			w.line("// Input checksum: %s", g.InputChecksum)
+		}
+		w.line("")
+		w.line(`syntax = "proto3";`)
+		w.line("")
+		w.line("package %s;", g.Package)
+		w.line("")
+		w.line(`option java_package = "%s.proto";`, g.Package)
+		w.line("option java_multiple_files = true;")
+		for _, table := range s.Tables {
+			w.line("")
+			renderProtoMessage(w, s, table)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return g.generateProtoConverters(s, models)
+}
+
+func renderProtoMessage(w *writer, s *schema.Schema, table *ast.Definition) {
+	w.line("message %s {", table.Name)
+	w.in()
+	number := 0
+	for _, field := range table.Fields {
+		if underlying := s.Types[field.Type.Name()]; underlying != nil && underlying.Kind == ast.Object {
+			continue
+		}
+		number++
+		fieldType := field.Type
+		repeated := ""
+		if fieldType.Elem != nil {
+			repeated = "repeated "
+			fieldType = fieldType.Elem
+		}
+		protoType, ok := protoScalarTypes[fieldType.NamedType]
+		if !ok {
+			protoType = "string"
+		}
+		optional := ""
+		if repeated == "" && !field.Type.NonNull {
+			optional = "optional "
+		}
+		w.line("%s%s%s %s = %d;", repeated, optional, protoType, field.Name, number)
+	}
+	w.out()
+	w.line("}")
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import "strings"
+
+// diffModel is the diff(key, old, new) helper generated for a mutation in
+// the canonical update shape, Variables(key:, data: <Table>_Data).
+type diffModel struct {
+	// Key and Data are the two Variables fields, and Class is the nested
+	// input class old and new are compared as.
+	Key   *fieldModel
+	Data  *fieldModel
+	Class *classModel
+}
+
+// diffFor recognizes the canonical update shape -- exactly a key field and
+// a data field holding a <Table>_Data class -- and prepares the diff helper
+// for it, or returns nil for any other shape.
+func diffFor(class *classModel) *diffModel {
+	if class == nil || len(class.Fields) != 2 {
+		return nil
+	}
+	key, data := class.field("key"), class.field("data")
+	if key == nil || data == nil {
+		return nil
+	}
+	dataClass := class.nested(trimNullability(data.Type))
+	if dataClass == nil || !strings.HasSuffix(dataClass.Name, "_Data") {
+		return nil
+	}
+	return &diffModel{Key: key, Data: data, Class: dataClass}
+}
+
+// renderDiffHelper emits the diff function: the returned Variables carries
+// new's values for fields that changed and leaves unchanged nullable fields
+// null, so they are omitted from the update payload. Non-null fields are
+// always sent, since null cannot stand in for "unchanged" there.
+func renderDiffHelper(w *writer, d *diffModel) {
+	w.line("/**")
+	w.line(" * Builds [Variables] sending only the fields that differ between")
+	w.line(" * [old] and [new]; unchanged nullable fields are left null and")
+	w.line(" * omitted from the update payload.")
+	w.line(" */")
+	w.line("public fun diff(%s: %s, old: %s, new: %s): Variables = Variables(",
+		d.Key.property(), d.Key.Type, d.Class.Name, d.Class.Name)
+	w.in()
+	w.line("%s = %s,", d.Key.property(), d.Key.property())
+	w.line("%s = %s(", d.Data.property(), d.Class.Name)
+	w.in()
+	for _, field := range d.Class.Fields {
+		if field.ServerExpr != "" {
+			continue
+		}
+		property := field.property()
+		switch {
+		case isByteArray(field.Type) && strings.HasSuffix(field.Type, "?"):
+			w.line("%s = if (!(new.%s contentEquals old.%s)) new.%s else null,",
+				property, property, property, property)
+		case strings.HasSuffix(field.Type, "?"):
+			w.line("%s = if (new.%s != old.%s) new.%s else null,",
+				property, property, property, property)
+		default:
+			w.line("%s = new.%s,", property, property)
+		}
+	}
+	w.out()
+	w.line("),")
+	w.out()
+	w.line(")")
+}
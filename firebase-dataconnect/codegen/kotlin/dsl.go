@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// generateQueryDSL writes a connector-scoped builder DSL for composing
+// ad-hoc queries at runtime. The per-operation classes cover the operations
+// a connector declares; the DSL covers apps that need to select fields
+// dynamically -- a configurable detail screen, say -- while keeping field
+// names type-checked. Each builder compiles down to GraphQL text for the
+// synthesized per-table query fields.
+func (g *Generator) generateQueryDSL(s *schema.Schema) error {
+	return g.writeFile(upperCamel(g.Connector)+"QueryBuilder.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, table := range s.Tables {
+			w.line("")
+			renderTableSelection(w, s, table)
+		}
+		for _, field := range s.Query.Fields {
+			table := listedTable(s, field)
+			if table == nil {
+				continue
+			}
+			w.line("")
+			renderTableQueryBuilder(w, field.Name, table)
+		}
+	})
+}
+
+// listedTable returns the @table type a query field lists, or nil if the
+// field is not one of the synthesized plural list fields.
+func listedTable(s *schema.Schema, field *ast.FieldDefinition) *ast.Definition {
+	if field.Type.Elem == nil || len(field.Arguments) > 0 {
+		return nil
+	}
+	for _, table := range s.Tables {
+		if field.Type.Elem.Name() == table.Name {
+			return table
+		}
+	}
+	return nil
+}
+
+// renderTableSelection writes the selection builder for one table: a method
+// per scalar field, so a selected field that no longer exists in the schema
+// fails at compile time rather than at the server.
+func renderTableSelection(w *writer, s *schema.Schema, table *ast.Definition) {
+	w.line("public class %sSelection internal constructor() {", table.Name)
+	w.in()
+	w.line("internal val fields: MutableList<String> = mutableListOf()")
+	for _, field := range table.Fields {
+		if underlying := s.Types[field.Type.Name()]; underlying != nil && underlying.Kind == ast.Object {
+			continue
+		}
+		w.line("")
+		w.line("public fun %s() {", propertyName(field.Name))
+		w.in()
+		w.line("fields += %q", field.Name)
+		w.out()
+		w.line("}")
+	}
+	w.out()
+	w.line("}")
+}
+
+// renderTableQueryBuilder writes the entry point building the GraphQL text
+// of a list query over one table.
+func renderTableQueryBuilder(w *writer, fieldName string, table *ast.Definition) {
+	w.line("public fun %sQuery(block: %sSelection.() -> Unit): String {", propertyName(fieldName), table.Name)
+	w.in()
+	w.line("val selection = %sSelection().apply(block)", table.Name)
+	w.line(`require(selection.fields.isNotEmpty()) { "select at least one field of %s" }`, table.Name)
+	w.line(`return "query { %s { " + selection.fields.joinToString(" ") + " } }"`, fieldName)
+	w.out()
+	w.line("}")
+}
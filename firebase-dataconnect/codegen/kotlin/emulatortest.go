@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// renderEmulatorTest writes an androidTest skeleton executing one operation
+// against the local Data Connect emulator and asserting that its response
+// decodes. The execution itself is a TODO -- wiring the connector instance
+// is app-specific -- so the skeleton compiles but fails until filled in,
+// giving teams an integration-test baseline per operation.
+func renderEmulatorTest(w *writer, m *operationModel) {
+	w.line("package %s", m.Package)
+	w.line("")
+	w.line("@org.junit.runner.RunWith(androidx.test.ext.junit.runners.AndroidJUnit4::class)")
+	w.line("public class %sEmulatorTest {", m.ClassName)
+	w.in()
+	w.line("@org.junit.Test")
+	w.line("public fun executeAndDecode(): Unit = kotlinx.coroutines.runBlocking {")
+	w.in()
+	w.line("// TODO: obtain your connector instance, pointed at the local")
+	w.line("// emulator via useEmulator(), and execute %s with it.", m.Name)
+	w.line(`val data: %s.Data = TODO("execute %s against the emulator")`, m.ClassName, m.Name)
+	w.line("org.junit.Assert.assertNotNull(data)")
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// noteEnum records a schema enum referenced while building models, so that
+// generateEnums declares it and sample generators can pick a valid value.
+func (g *Generator) noteEnum(def *ast.Definition) {
+	if g.usedEnums == nil {
+		g.usedEnums = map[string]*ast.Definition{}
+	}
+	g.usedEnums[def.Name] = def
+}
+
+// generateEnums writes Enums.kt, declaring a Kotlin enum class for every
+// schema enum the generated classes reference. Entries keep their GraphQL
+// names and carry an explicit serialized-name binding, so a Kotlin-side
+// rename can never silently change the wire value.
+func (g *Generator) generateEnums() error {
+	if len(g.usedEnums) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(g.usedEnums))
+	for name := range g.usedEnums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return g.writeFile("Enums.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, name := range names {
+			def := g.usedEnums[name]
+			w.line("")
+			renderKDoc(w, def.Description)
+			if g.Serialization == "" {
+				w.line("@kotlinx.serialization.Serializable")
+			}
+			w.line("public enum class %s {", def.Name)
+			w.in()
+			for _, value := range def.EnumValues {
+				switch g.Serialization {
+				case "":
+					w.line("@kotlinx.serialization.SerialName(%q)", value.Name)
+				case "moshi":
+					w.line("@com.squareup.moshi.Json(name = %q)", value.Name)
+				case "gson":
+					w.line("@com.google.gson.annotations.SerializedName(%q)", value.Name)
+				}
+				w.line("%s,", value.Name)
+			}
+			w.out()
+			w.line("}")
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// Explain prints the computed class structure of the named operation --
+// Variables, nested input classes, convenience constructors, and the Data
+// shape -- without writing any files, for debugging unexpected generated
+// APIs.
+func (g *Generator) Explain(w io.Writer, s *schema.Schema, operations []*schema.Operation, name string) error {
+	var names []string
+	for _, op := range operations {
+		if op.Name == name {
+			model, err := g.operationModel(s, op)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op.SourcePath, err)
+			}
+			explainModel(w, model)
+			return nil
+		}
+		names = append(names, op.Name)
+	}
+	return fmt.Errorf("no operation named %q; the connector defines: %s",
+		name, strings.Join(names, ", "))
+}
+
+func explainModel(w io.Writer, m *operationModel) {
+	fmt.Fprintf(w, "%s (%s %s)\n", m.ClassName, m.Kind, m.Name)
+	if m.Variables == nil {
+		fmt.Fprintf(w, "  (no variables)\n")
+	} else {
+		explainClass(w, m.Variables, "  ")
+	}
+	explainClass(w, m.Data, "  ")
+}
+
+func explainClass(w io.Writer, c *classModel, indent string) {
+	fmt.Fprintf(w, "%s%s\n", indent, c.Name)
+	for _, field := range c.Fields {
+		markers := ""
+		if field.Redacted {
+			markers += "  [redacted]"
+		}
+		if field.ServerExpr != "" {
+			markers += fmt.Sprintf("  [server: %s]", field.ServerExpr)
+		} else if field.HasDefault {
+			markers += "  [defaulted]"
+		}
+		if field.ForcedNonNull {
+			markers += "  [non-null by @ktNonNull]"
+		}
+		fmt.Fprintf(w, "%s  val %s: %s%s\n", indent, field.property(), field.Type, markers)
+	}
+	for _, constructor := range c.Constructors {
+		if constructor.Vararg {
+			fmt.Fprintf(w, "%s  constructor(vararg %s: %s)\n",
+				indent, constructor.Field.property(), constructor.Class.Name)
+			continue
+		}
+		params := constructorParams(constructor, nil)
+		if constructor.Builder {
+			fmt.Fprintf(w, "%s  fun %s(%s): %s\n",
+				indent, constructor.Field.property(), strings.Join(params, ", "), constructor.Class.Name)
+			continue
+		}
+		fmt.Fprintf(w, "%s  constructor(%s)\n", indent, strings.Join(params, ", "))
+	}
+	for _, nested := range c.Nested {
+		explainClass(w, nested, indent+"  ")
+	}
+}
+
+// constructorParams collects a flattening constructor's parameter list for
+// display, recursing into the nested levels flattened in place.
+func constructorParams(c *constructorModel, params []string) []string {
+	for _, field := range c.Class.Fields {
+		if field.ServerExpr != "" {
+			continue
+		}
+		if sub := c.Nested[field.Name]; sub != nil {
+			params = constructorParams(sub, params)
+			continue
+		}
+		params = append(params, fmt.Sprintf("%s: %s", c.paramName(field), field.Type))
+	}
+	return params
+}
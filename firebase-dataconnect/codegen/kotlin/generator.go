@@ -0,0 +1,667 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kotlin generates the Kotlin sources for a Data Connect connector.
+package kotlin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/stats"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Generator generates the Kotlin sources for a single connector.
+type Generator struct {
+	// Package is the Kotlin package of the generated sources.
+	Package string
+
+	// Connector is the name of the connector, as declared in its
+	// connector.yaml.
+	Connector string
+
+	// DestDir is the directory the generated sources are written to; the
+	// package's directory structure is created beneath it.
+	DestDir string
+
+	// Serialization selects the serialization library the generated classes
+	// are annotated for: "" (none), "moshi", or "gson".
+	Serialization string
+
+	// RoomEntities also generates a Room @Entity class per @table type.
+	RoomEntities bool
+
+	// DataStoreProto also generates a proto3 schema for Proto DataStore with
+	// one message per @table type.
+	DataStoreProto bool
+
+	// ComposePreviews also generates a Compose PreviewParameterProvider per
+	// operation, supplying a sample Data instance.
+	ComposePreviews bool
+
+	// LiveData also generates an asLiveData extension per query.
+	LiveData bool
+
+	// RxJava also generates an asSingle extension per operation.
+	RxJava bool
+
+	// ResultWrapper also generates the shared OperationResult sealed class.
+	ResultWrapper bool
+
+	// KotlinxDatetime maps the Date and Timestamp scalars to kotlinx-datetime
+	// types instead of strings.
+	KotlinxDatetime bool
+
+	// ScalarMappings maps custom scalar names to the Kotlin types generated
+	// code uses for them, e.g. UUID to java.util.UUID or Int64 to Long. An
+	// entry here wins over KotlinxDatetime and the built-in defaults.
+	ScalarMappings map[string]string
+
+	// ValueClasses generates an inline value class per single-field table
+	// key and uses it in generated _Key classes.
+	ValueClasses bool
+
+	// TypedKeys generates one shared <Table>Key class per table and uses
+	// it both for key-typed variables and for the key selections returned
+	// by insert, update, and delete mutations, in place of per-operation
+	// nested key classes.
+	TypedKeys bool
+
+	// DataMappers generates extension functions converting response
+	// classes back into the <Table>_Data input classes whose fields they
+	// selected, for echoing fetched rows into updates.
+	DataMappers bool
+
+	// DiffHelpers generates a diff(key, old, new) function on mutations in
+	// the canonical update shape, building Variables that send only the
+	// changed fields.
+	DiffHelpers bool
+
+	// ShardByKind generates queries and mutations into separate
+	// subpackages of Package (.queries and .mutations).
+	ShardByKind bool
+
+	// SingleFile renders every operation of the connector into one Kotlin
+	// file instead of one file per operation.
+	SingleFile bool
+
+	// SeedScripts also writes an insert mutation per @table type plus a
+	// manifest, for seeding the local emulator with sample rows.
+	SeedScripts bool
+
+	// UnitTests also generates a JVM unit test per operation deserializing
+	// a sample JSON payload into the generated Data class.
+	UnitTests bool
+
+	// Connections also generates the shared ConnectionPager helper for the
+	// synthesized cursor-based connection query fields.
+	Connections bool
+
+	// AuthGuards also generates a checkAuth precondition per operation
+	// from its @auth level.
+	AuthGuards bool
+
+	// EmulatorTests also generates an androidTest skeleton per operation
+	// executing it against the local Data Connect emulator.
+	EmulatorTests bool
+
+	// GrpcStubs also generates a thin typed wrapper per operation over the
+	// low-level ExecuteQuery/ExecuteMutation gRPC surface.
+	GrpcStubs bool
+
+	// ConnectorClass also generates the <Connector>Connector class, one
+	// typed execute handle per operation behind a caller-supplied
+	// transport.
+	ConnectorClass bool
+
+	// ProguardRules also writes the R8/ProGuard keep rules the generated
+	// classes need in minified builds.
+	ProguardRules bool
+
+	// QueryDSL also generates a connector-scoped builder DSL for composing
+	// ad-hoc list queries at runtime.
+	QueryDSL bool
+
+	// ResourceOperations writes each operation's GraphQL text as a packaged
+	// resource file and generates operationSource to read it from the
+	// classpath, instead of embedding the text as a string constant.
+	ResourceOperations bool
+
+	// InputChecksum, when set, is stamped into every generated file's
+	// header so build tooling can detect stale outputs.
+	InputChecksum string
+
+	// MaxDepth limits how deeply generated classes may nest when expanding
+	// selection sets and input-object types. Zero means the default of 20.
+	MaxDepth int
+
+	// MaxFlattenedParams limits how many parameters a flattening convenience
+	// constructor may take. Zero means the default of 254, the most the JVM
+	// allows alongside the receiver.
+	MaxFlattenedParams int
+
+	// FlattenDepth is how many levels of non-null input objects a
+	// flattening convenience constructor expands into direct parameters;
+	// objects beyond the depth are passed whole. Zero means the default
+	// of 1.
+	FlattenDepth int
+
+	// NoConvenienceConstructors omits the flattening convenience
+	// constructors entirely, for teams that want only the primary
+	// data-class constructors.
+	NoConvenienceConstructors bool
+
+	// Builders replaces the flattening constructors with named companion
+	// builder functions, one per input-object variable, so two input types
+	// with identical scalar signatures cannot produce ambiguous overloads.
+	Builders bool
+
+	// KeepGoing continues generating the remaining operations when one of
+	// them fails, reporting every failure at the end, instead of aborting at
+	// the first.
+	KeepGoing bool
+
+	// Jobs bounds how many operations render concurrently. Zero means one
+	// worker per available CPU.
+	Jobs int
+
+	// Incremental skips rewriting generated files whose inputs are
+	// unchanged since the last run, tracked through a hash manifest
+	// beneath DestDir, so untouched outputs keep their timestamps and
+	// downstream build caching holds.
+	Incremental bool
+
+	// TemplateHookDir, when set, is a directory whose optional
+	// header.gotmpl and footer.gotmpl are rendered around every generated
+	// file.
+	TemplateHookDir string
+
+	// TemplateDir, when set, is a directory whose operation.gotmpl
+	// replaces the built-in rendering of each operation's Kotlin file,
+	// for teams wanting a different code style without forking the tool.
+	// Missing templates fall back to the built-in renderer.
+	TemplateDir string
+
+	// FilesWritten counts the files Generate wrote; it is filled in when
+	// Generate returns, for end-of-run reporting. FilesSkipped and
+	// FilesPruned are the incremental-mode counterparts: outputs left
+	// untouched and stale outputs removed.
+	FilesWritten int
+	FilesSkipped int
+	FilesPruned  int
+
+	// filesWritten is the running count, incremented atomically because
+	// operation workers write files concurrently.
+	filesWritten int64
+
+	// hooks are the parsed TemplateHookDir templates, loaded by Generate,
+	// and overrides the parsed TemplateDir templates.
+	hooks     *templateHooks
+	overrides map[string]*template.Template
+
+	// usedEnums records the schema enums referenced while building models,
+	// keyed by name, so Generate can declare them in Enums.kt and sample
+	// generators can pick valid enum literals.
+	usedEnums map[string]*ast.Definition
+
+	// manifest tracks input and output hashes under Incremental; nil
+	// otherwise.
+	manifest *manifestTracker
+}
+
+const (
+	defaultMaxDepth           = 20
+	defaultMaxFlattenedParams = 254
+	defaultFlattenDepth       = 1
+)
+
+func (g *Generator) maxDepth() int {
+	if g.MaxDepth > 0 {
+		return g.MaxDepth
+	}
+	return defaultMaxDepth
+}
+
+func (g *Generator) maxFlattenedParams() int {
+	if g.MaxFlattenedParams > 0 {
+		return g.MaxFlattenedParams
+	}
+	return defaultMaxFlattenedParams
+}
+
+func (g *Generator) flattenDepth() int {
+	if g.FlattenDepth > 0 {
+		return g.FlattenDepth
+	}
+	return defaultFlattenDepth
+}
+
+func (g *Generator) jobs() int {
+	if g.Jobs > 0 {
+		return g.Jobs
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// Generate writes one Kotlin file per operation, plus the connector-level
+// and optional supporting files. When KeepGoing is set, an operation that
+// fails does not stop the remaining ones; every failure is returned at the
+// end.
+func (g *Generator) Generate(s *schema.Schema, operations []*schema.Operation) error {
+	defer func() {
+		g.FilesWritten = int(atomic.LoadInt64(&g.filesWritten))
+		if g.manifest != nil {
+			g.FilesSkipped = g.manifest.skipped
+			g.FilesPruned = g.manifest.pruned
+		}
+	}()
+	if err := g.registerConnector(); err != nil {
+		return err
+	}
+	if g.Incremental && g.InputChecksum != "" {
+		g.manifest = g.loadManifestTracker()
+	}
+	if err := g.generateSourceDescriptor(); err != nil {
+		return err
+	}
+	hooks, err := loadTemplateHooks(g.TemplateHookDir)
+	if err != nil {
+		return err
+	}
+	g.hooks = hooks
+	overrides, err := loadTemplateOverrides(g.TemplateDir)
+	if err != nil {
+		return err
+	}
+	g.overrides = overrides
+	var models []*operationModel
+	var failures []error
+	for _, op := range operations {
+		model, err := g.operationModel(s, op)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", op.SourcePath, err)
+			if !g.KeepGoing {
+				return err
+			}
+			failures = append(failures, err)
+			continue
+		}
+		if g.ShardByKind && !model.PackageOverridden {
+			model.Package += "." + kindSubpackages[model.Kind]
+		}
+		models = append(models, model)
+	}
+	if g.SingleFile {
+		if err := g.writeFile(upperCamel(g.Connector)+"Operations.kt", func(w *writer) {
+			w.line("package %s", g.Package)
+			for _, model := range models {
+				w.line("")
+				renderOperationObject(w, model)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	for _, err := range g.renderOperations(models) {
+		if err == nil {
+			continue
+		}
+		if !g.KeepGoing {
+			return err
+		}
+		failures = append(failures, err)
+	}
+	if err := g.generateOperationConstants(models); err != nil {
+		return err
+	}
+	if g.ConnectorClass {
+		if err := g.generateConnectorClass(models); err != nil {
+			return err
+		}
+	}
+	if g.DataMappers {
+		if err := g.generateDataMappers(s, models); err != nil {
+			return err
+		}
+	}
+	if err := g.generateEnums(); err != nil {
+		return err
+	}
+	if g.RoomEntities {
+		if err := g.generateRoomEntities(s); err != nil {
+			return err
+		}
+	}
+	if g.DataStoreProto {
+		if err := g.generateDataStoreProto(s, models); err != nil {
+			return err
+		}
+	}
+	if g.ValueClasses {
+		if err := g.generateValueClasses(s); err != nil {
+			return err
+		}
+	}
+	if g.TypedKeys {
+		if err := g.generateKeyClasses(s); err != nil {
+			return err
+		}
+	}
+	if g.SeedScripts {
+		if err := g.generateSeedScripts(s); err != nil {
+			return err
+		}
+	}
+	if g.ProguardRules {
+		if err := g.generateProguardRules(models); err != nil {
+			return err
+		}
+	}
+	if g.QueryDSL {
+		if err := g.generateQueryDSL(s); err != nil {
+			return err
+		}
+	}
+	if usesLatLng(s) {
+		if err := g.writeFile("LatLng.kt", func(w *writer) {
+			renderLatLng(w, g.Package)
+		}); err != nil {
+			return err
+		}
+	}
+	if usesBytes(s) {
+		if err := g.writeFile("ByteArrayBase64Serializer.kt", func(w *writer) {
+			renderBytesSerializer(w, g.Package)
+		}); err != nil {
+			return err
+		}
+	}
+	if g.Connections {
+		if err := g.writeFile("ConnectionPager.kt", func(w *writer) {
+			renderConnectionPager(w, g.Package)
+		}); err != nil {
+			return err
+		}
+	}
+	if g.ResultWrapper {
+		if err := g.writeFile("OperationResult.kt", func(w *writer) {
+			renderResultWrapper(w, g.Package)
+		}); err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d operation(s) failed:\n%w", len(failures), errors.Join(failures...))
+	}
+	// The manifest is only saved after a fully successful run; saving a
+	// partial output set would prune the files the failed operations own.
+	if g.manifest != nil {
+		if err := g.saveManifest(g.manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderOperations writes each model's files, rendering up to jobs()
+// operations concurrently. Errors come back indexed by operation so that
+// reporting order matches operation order regardless of scheduling; without
+// KeepGoing the first failure also stops queued operations from starting.
+func (g *Generator) renderOperations(models []*operationModel) []error {
+	errs := make([]error, len(models))
+	workers := g.jobs()
+	if workers > len(models) {
+		workers = len(models)
+	}
+	if workers <= 1 {
+		for i, model := range models {
+			if errs[i] = g.generateOperationFiles(model); errs[i] != nil && !g.KeepGoing {
+				break
+			}
+		}
+		return errs
+	}
+	var stop atomic.Bool
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if stop.Load() {
+					continue
+				}
+				if errs[i] = g.generateOperationFiles(models[i]); errs[i] != nil && !g.KeepGoing {
+					stop.Store(true)
+				}
+			}
+		}()
+	}
+	for i := range models {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+// generateOperationFiles writes the Kotlin file for one operation along with
+// its optional per-operation companions.
+func (g *Generator) generateOperationFiles(model *operationModel) error {
+	defer stats.Time("render " + model.ClassName)()
+	if g.ResourceOperations {
+		if err := g.writeOperationResource(model); err != nil {
+			return err
+		}
+	}
+	if !g.SingleFile {
+		render := func(w *writer) {
+			renderOperation(w, model)
+		}
+		if tmpl := g.overrides[operationTemplateName]; tmpl != nil {
+			render = func(w *writer) {
+				w.fail(tmpl.Execute(w.dest, operationTemplateData{
+					templateData: templateData{
+						Package:   model.Package,
+						Connector: g.Connector,
+						File:      model.ClassName + ".kt",
+						Checksum:  g.InputChecksum,
+					},
+					Name:      model.Name,
+					ClassName: model.ClassName,
+					Kind:      string(model.Kind),
+					Source:    model.Source,
+					Doc:       model.Doc,
+				}))
+			}
+		}
+		if err := g.writeRendered(model.Package, "", model.ClassName+".kt", render); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("composePreviews", g.ComposePreviews) {
+		if err := g.writeRendered(model.Package, "", model.ClassName+"DataPreviewParameterProvider.kt", func(w *writer) {
+			g.renderPreviewProvider(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("liveData", g.LiveData) && model.Kind == ast.Query {
+		if err := g.writeRendered(model.Package, "", model.ClassName+"LiveData.kt", func(w *writer) {
+			renderLiveDataWrapper(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("rxJava", g.RxJava) {
+		if err := g.writeRendered(model.Package, "", model.ClassName+"Rx.kt", func(w *writer) {
+			renderRxJavaAdapter(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("grpcStubs", g.GrpcStubs) {
+		if err := g.writeRendered(model.Package, "", model.ClassName+"GrpcStub.kt", func(w *writer) {
+			renderGrpcStub(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("emulatorTests", g.EmulatorTests) {
+		if err := g.writeRendered(model.Package, "androidTest", model.ClassName+"EmulatorTest.kt", func(w *writer) {
+			renderEmulatorTest(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	if model.boolOption("unitTests", g.UnitTests) {
+		if err := g.writeRendered(model.Package, "test", model.ClassName+"DecodeTest.kt", func(w *writer) {
+			g.renderDecodeTest(w, model)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kindSubpackages maps an operation kind to the subpackage it shards into
+// under ShardByKind.
+var kindSubpackages = map[ast.Operation]string{
+	ast.Query:        "queries",
+	ast.Mutation:     "mutations",
+	ast.Subscription: "subscriptions",
+}
+
+// connectorRegistryName is the file recording which connectors have
+// generated into a dest dir, with their normalized names and packages.
+const connectorRegistryName = ".dataconnect-connectors"
+
+// registerConnector guards a dest dir shared by several connectors. Two
+// connector names that normalize to the same generated prefix (differing
+// only in case or separators), or two connectors generating into the same
+// Kotlin package, would silently overwrite each other's files; the dest dir
+// carries a small registry so the second run fails instead.
+func (g *Generator) registerConnector() error {
+	normalized := strings.ToLower(upperCamel(g.Connector))
+	path := filepath.Join(g.DestDir, connectorRegistryName)
+	contents, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := []string{strings.Join([]string{g.Connector, normalized, g.Package}, "\t")}
+	for _, line := range strings.Split(string(contents), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 || parts[0] == g.Connector {
+			continue
+		}
+		if parts[1] == normalized {
+			return fmt.Errorf(
+				"connector %q collides with connector %q already generated into %s: both normalize to %q; use distinct names or dest dirs",
+				g.Connector, parts[0], g.DestDir, normalized)
+		}
+		if strings.EqualFold(parts[2], g.Package) {
+			return fmt.Errorf(
+				"connector %q would generate into package %s, already used by connector %q in %s; use distinct packages or dest dirs",
+				g.Connector, g.Package, parts[0], g.DestDir)
+		}
+		lines = append(lines, line)
+	}
+	if err := os.MkdirAll(g.DestDir, 0o777); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o666)
+}
+
+// writeOperationResource writes the operation's GraphQL text under the
+// resources directory and records the classpath location on the model.
+func (g *Generator) writeOperationResource(m *operationModel) error {
+	resourcePath := "dataconnect/" + g.Connector + "/" + m.Name + ".gql"
+	m.ResourcePath = resourcePath
+	path := filepath.Join(g.DestDir, "resources", filepath.FromSlash(resourcePath))
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return err
+	}
+	g.countFile()
+	return os.WriteFile(path, []byte(m.Source+"\n"), 0o666)
+}
+
+// countFile notes one written file for end-of-run reporting; operation
+// workers call it concurrently.
+func (g *Generator) countFile() {
+	atomic.AddInt64(&g.filesWritten, 1)
+}
+
+// writeFile renders one Kotlin file and streams it beneath DestDir in the
+// generated package's directory.
+func (g *Generator) writeFile(name string, render func(w *writer)) error {
+	return g.writeRendered(g.Package, "", name, render)
+}
+
+// writeSourceSetFile is writeFile for a non-main source set, whose files
+// live in a directory of the source set's name beneath DestDir.
+func (g *Generator) writeSourceSetFile(sourceSet, name string, render func(w *writer)) error {
+	return g.writeRendered(g.Package, sourceSet, name, render)
+}
+
+// writeRendered renders one file into the given package's directory of the
+// given source set ("" for main). Under Incremental, a file whose inputs
+// and recorded contents are unchanged is left untouched.
+func (g *Generator) writeRendered(pkg, sourceSet, name string, render func(w *writer)) error {
+	dir := filepath.Join(g.DestDir, sourceSet, filepath.FromSlash(strings.ReplaceAll(pkg, ".", "/")))
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return err
+	}
+	target := filepath.Join(dir, name)
+	rel := path.Join(sourceSet, strings.ReplaceAll(pkg, ".", "/"), name)
+	if g.manifest.shouldSkip(rel, target) {
+		return nil
+	}
+	g.countFile()
+	data := templateData{Package: pkg, Connector: g.Connector, File: name, Checksum: g.InputChecksum}
+	if err := streamFile(target, func(w *writer) {
+		if g.hooks != nil && g.hooks.header != nil {
+			w.fail(g.hooks.header.Execute(w.dest, data))
+		}
+		w.line("// Generated by the Firebase Data Connect Kotlin code generator. Do not edit.")
+		if g.InputChecksum != "" {
+			w.line("// Input checksum: %s", g.InputChecksum)
+		}
+		w.line("")
+		render(w)
+		if g.hooks != nil && g.hooks.footer != nil {
+			w.fail(g.hooks.footer.Execute(w.dest, data))
+		}
+	}); err != nil {
+		return err
+	}
+	if g.manifest != nil {
+		contents, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		g.manifest.record(rel, schema.DocumentChecksum(string(contents)))
+	}
+	return nil
+}
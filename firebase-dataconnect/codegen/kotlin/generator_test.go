@@ -0,0 +1,600 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+const testSchemaGQL = `
+type Movie @table {
+  id: UUID!
+  title: String!
+  releaseYear: Int
+}
+`
+
+const testOperationsGQL = `
+query ListMovies {
+  movies { id title releaseYear }
+}
+
+query GetMovie($key: Movie_Key!) {
+  movie(key: $key) { id title releaseYear }
+}
+`
+
+// writeTestInputs lays out a schema and connector directory and returns the
+// loaded inputs.
+func writeTestInputs(t *testing.T) (*schema.Schema, []*schema.Operation) {
+	t.Helper()
+	return loadTestInputs(t, testSchemaGQL, testOperationsGQL)
+}
+
+// loadTestInputs lays out the given schema and connector sources and returns
+// the loaded inputs.
+func loadTestInputs(t *testing.T, schemaGQL, operationsGQL string) (*schema.Schema, []*schema.Operation) {
+	t.Helper()
+	root := t.TempDir()
+	schemaDir := filepath.Join(root, "schema")
+	connectorDir := filepath.Join(root, "connector")
+	for dir, contents := range map[string]string{
+		schemaDir:    schemaGQL,
+		connectorDir: operationsGQL,
+	} {
+		if err := os.MkdirAll(dir, 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "main.gql"), []byte(contents), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s, err := schema.Load(schemaDir)
+	if err != nil {
+		t.Fatalf("schema.Load() failed: %v", err)
+	}
+	operations, err := schema.LoadOperations(s, connectorDir)
+	if err != nil {
+		t.Fatalf("schema.LoadOperations() failed: %v", err)
+	}
+	return s, operations
+}
+
+// TestListNullabilityMatrix checks that list and element nullability map
+// independently: [String]! is a non-null list of nullable elements,
+// [String!]! is non-null throughout, and so on. Collapsing any of the four
+// cases would silently change generated signatures.
+func TestListNullabilityMatrix(t *testing.T) {
+	s, operations := loadTestInputs(t, `
+type Thing @table(key: ["id", "tags", "labels"]) {
+  id: UUID!
+  tags: [String]!
+  labels: [String!]!
+  notes: [String!]
+  extras: [String]
+}
+`, `
+query GetThing($key: Thing_Key!) {
+  thing(key: $key) { tags labels notes extras }
+}
+`)
+	if len(operations) != 1 {
+		t.Fatalf("loaded %d operations, want 1", len(operations))
+	}
+	generator := &Generator{Package: "com.example.test", Connector: "test-connector"}
+	model, err := generator.operationModel(s, operations[0])
+	if err != nil {
+		t.Fatalf("operationModel() failed: %v", err)
+	}
+	thing := model.Data.nested("Thing")
+	if thing == nil {
+		t.Fatal("Data has no nested Thing class")
+	}
+	for _, want := range []struct{ field, kotlinType string }{
+		{"tags", "List<String?>"},
+		{"labels", "List<String>"},
+		{"notes", "List<String>?"},
+		{"extras", "List<String?>?"},
+	} {
+		field := thing.field(want.field)
+		if field == nil {
+			t.Errorf("Thing has no %s field", want.field)
+			continue
+		}
+		if field.Type != want.kotlinType {
+			t.Errorf("Thing.%s is %s, want %s", want.field, field.Type, want.kotlinType)
+		}
+	}
+	// Key fields are forced non-null, but element nullability must survive
+	// the trip through the synthesized Thing_Key input type.
+	key := model.Variables.nested("Thing_Key")
+	if key == nil {
+		t.Fatal("Variables has no nested Thing_Key class")
+	}
+	for _, want := range []struct{ field, kotlinType string }{
+		{"tags", "List<String?>"},
+		{"labels", "List<String>"},
+	} {
+		field := key.field(want.field)
+		if field == nil {
+			t.Errorf("Thing_Key has no %s field", want.field)
+			continue
+		}
+		if field.Type != want.kotlinType {
+			t.Errorf("Thing_Key.%s is %s, want %s", want.field, field.Type, want.kotlinType)
+		}
+	}
+}
+
+// TestFragmentSelections covers named and inline fragments in selection
+// sets: both are flattened into the enclosing class, and a field selected
+// again through a fragment does not duplicate a property.
+func TestFragmentSelections(t *testing.T) {
+	s, operations := loadTestInputs(t, testSchemaGQL, `
+fragment MovieFields on Movie {
+  id
+  title
+}
+
+query ListMoviesWithFragments {
+  movies {
+    ...MovieFields
+    ... on Movie {
+      title
+      releaseYear
+    }
+  }
+}
+`)
+	if len(operations) != 1 {
+		t.Fatalf("loaded %d operations, want 1", len(operations))
+	}
+	generator := &Generator{Package: "com.example.test", Connector: "test-connector"}
+	model, err := generator.operationModel(s, operations[0])
+	if err != nil {
+		t.Fatalf("operationModel() failed: %v", err)
+	}
+	item := model.Data.nested("MoviesItem")
+	if item == nil {
+		t.Fatal("Data has no nested MoviesItem class")
+	}
+	if len(item.Fields) != 3 {
+		t.Fatalf("MoviesItem has %d fields, want 3", len(item.Fields))
+	}
+	for _, want := range []struct{ field, kotlinType string }{
+		{"id", "String"},
+		{"title", "String"},
+		{"releaseYear", "Int?"},
+	} {
+		field := item.field(want.field)
+		if field == nil {
+			t.Errorf("MoviesItem has no %s field", want.field)
+			continue
+		}
+		if field.Type != want.kotlinType {
+			t.Errorf("MoviesItem.%s is %s, want %s", want.field, field.Type, want.kotlinType)
+		}
+	}
+}
+
+// TestListVariableConstructors covers a list-of-input-object variable, as
+// used by bulk inserts: the Variables property is a List of the input
+// class and the convenience constructor takes the elements as a vararg.
+func TestListVariableConstructors(t *testing.T) {
+	s, operations := loadTestInputs(t, testSchemaGQL, `
+mutation AddMovies($data: [Movie_Data!]!) {
+  movie_insertMany(data: $data) {
+    id
+  }
+}
+`)
+	if len(operations) != 1 {
+		t.Fatalf("loaded %d operations, want 1", len(operations))
+	}
+	generator := &Generator{Package: "com.example.test", Connector: "test-connector"}
+	model, err := generator.operationModel(s, operations[0])
+	if err != nil {
+		t.Fatalf("operationModel() failed: %v", err)
+	}
+	data := model.Variables.field("data")
+	if data == nil {
+		t.Fatal("Variables has no data field")
+	}
+	if data.Type != "List<Movie_Data>" {
+		t.Errorf("Variables.data is %s, want List<Movie_Data>", data.Type)
+	}
+	if len(model.Variables.Constructors) != 1 {
+		t.Fatalf("Variables has %d constructors, want 1", len(model.Variables.Constructors))
+	}
+	constructor := model.Variables.Constructors[0]
+	if !constructor.Vararg {
+		t.Error("constructor for a list variable is not vararg")
+	}
+	if constructor.Class.Name != "Movie_Data" {
+		t.Errorf("constructor element class is %s, want Movie_Data", constructor.Class.Name)
+	}
+}
+
+// TestEnumTypes checks that a schema enum maps to its own Kotlin type, both
+// when it appears in a response selection and when it is nested inside an
+// input class, and that the generator records it for declaration.
+func TestEnumTypes(t *testing.T) {
+	s, operations := loadTestInputs(t, `
+enum Rating {
+  G
+  PG
+  R
+}
+
+type Movie @table {
+  id: UUID!
+  title: String!
+  rating: Rating
+}
+`, `
+query ListMovies {
+  movies { id rating }
+}
+
+mutation AddMovie($data: Movie_Data!) {
+  movie_insert(data: $data) {
+    id
+  }
+}
+`)
+	if len(operations) != 2 {
+		t.Fatalf("loaded %d operations, want 2", len(operations))
+	}
+	generator := &Generator{Package: "com.example.test", Connector: "test-connector"}
+	for _, operation := range operations {
+		model, err := generator.operationModel(s, operation)
+		if err != nil {
+			t.Fatalf("operationModel(%s) failed: %v", operation.Name, err)
+		}
+		switch operation.Name {
+		case "ListMovies":
+			item := model.Data.nested("MoviesItem")
+			if item == nil {
+				t.Fatal("Data has no nested MoviesItem class")
+			}
+			if field := item.field("rating"); field == nil {
+				t.Error("MoviesItem has no rating field")
+			} else if field.Type != "Rating?" {
+				t.Errorf("MoviesItem.rating is %s, want Rating?", field.Type)
+			}
+		case "AddMovie":
+			data := model.Variables.nested("Movie_Data")
+			if data == nil {
+				t.Fatal("Variables has no nested Movie_Data class")
+			}
+			if field := data.field("rating"); field == nil {
+				t.Error("Movie_Data has no rating field")
+			} else if field.Type != "Rating?" {
+				t.Errorf("Movie_Data.rating is %s, want Rating?", field.Type)
+			}
+		}
+	}
+	if generator.usedEnums["Rating"] == nil {
+		t.Error("generator did not record the Rating enum for declaration")
+	}
+}
+
+// TestDecodeTestSampleValues checks that the generated decode test's sample
+// payload is valid for non-string field types: an enum field gets its first
+// declared value and a UUID-mapped field a parseable UUID, so the generated
+// assert does not throw before it can catch real regressions. The insert
+// mutation's key selection is the non-null path such fields decode through.
+func TestDecodeTestSampleValues(t *testing.T) {
+	s, operations := loadTestInputs(t, `
+enum Rating {
+  G
+  PG
+  R
+}
+
+type Movie @table(key: ["id", "rating"]) {
+  id: UUID!
+  rating: Rating!
+  title: String!
+}
+`, `
+mutation AddMovie($data: Movie_Data!) {
+  movie_insert(data: $data) { id rating }
+}
+`)
+	destDir := t.TempDir()
+	generator := &Generator{
+		Package:        "com.example.test",
+		Connector:      "test-connector",
+		DestDir:        destDir,
+		UnitTests:      true,
+		ScalarMappings: map[string]string{"UUID": "java.util.UUID"},
+	}
+	if err := generator.Generate(s, operations); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	contents, err := os.ReadFile(
+		filepath.Join(destDir, "test", "com", "example", "test", "AddMovieMutationDecodeTest.kt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(contents, []byte(`"rating": "G"`)) {
+		t.Errorf("sample payload does not use the first enum value:\n%s", contents)
+	}
+	if !bytes.Contains(contents, []byte(`"id": "00000000-0000-4000-8000-000000000000"`)) {
+		t.Errorf("sample payload does not use a parseable UUID:\n%s", contents)
+	}
+	if bytes.Contains(contents, []byte(`"sample"`)) {
+		t.Errorf("sample payload fell back to the string literal for a non-string field:\n%s", contents)
+	}
+}
+
+// TestScalarMappings checks that a configured scalar override replaces the
+// serialized-string default in generated field types.
+func TestScalarMappings(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	generator := &Generator{
+		Package:        "com.example.test",
+		Connector:      "test-connector",
+		ScalarMappings: map[string]string{"UUID": "java.util.UUID"},
+	}
+	model, err := generator.operationModel(s, operations[0])
+	if err != nil {
+		t.Fatalf("operationModel() failed: %v", err)
+	}
+	item := model.Data.nested("MoviesItem")
+	if item == nil {
+		t.Fatal("Data has no nested MoviesItem class")
+	}
+	if field := item.field("id"); field == nil {
+		t.Error("MoviesItem has no id field")
+	} else if field.Type != "java.util.UUID" {
+		t.Errorf("MoviesItem.id is %s, want java.util.UUID", field.Type)
+	}
+}
+
+// TestIncrementalSecondRunSkips runs incremental generation twice over
+// unchanged inputs: the second run must leave every output untouched so
+// downstream build caching holds.
+func TestIncrementalSecondRunSkips(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	destDir := t.TempDir()
+	for run := 0; run < 2; run++ {
+		generator := &Generator{
+			Package:       "com.example.test",
+			Connector:     "test-connector",
+			DestDir:       destDir,
+			Incremental:   true,
+			InputChecksum: schema.Checksum(s, operations, "", ""),
+		}
+		if err := generator.Generate(s, operations); err != nil {
+			t.Fatalf("Generate() run %d failed: %v", run, err)
+		}
+		if run == 0 {
+			if generator.FilesSkipped != 0 {
+				t.Errorf("first run skipped %d files, want 0", generator.FilesSkipped)
+			}
+			continue
+		}
+		if generator.FilesWritten != 0 {
+			t.Errorf("second run rewrote %d files, want 0", generator.FilesWritten)
+		}
+		if generator.FilesSkipped == 0 {
+			t.Error("second run skipped no files")
+		}
+	}
+}
+
+// TestVerifyDetectsStaleOutputs checks verify mode against both states:
+// freshly generated files verify cleanly, and the same files are flagged
+// once the current inputs hash differently from what their headers record.
+func TestVerifyDetectsStaleOutputs(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	generator := &Generator{
+		Package:       "com.example.test",
+		Connector:     "test-connector",
+		DestDir:       t.TempDir(),
+		InputChecksum: schema.Checksum(s, operations, "", ""),
+	}
+	if err := generator.Generate(s, operations); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if err := generator.Verify(); err != nil {
+		t.Errorf("Verify() right after generation failed: %v", err)
+	}
+	generator.InputChecksum = "sha256:000000000000"
+	err := generator.Verify()
+	if err == nil {
+		t.Fatal("Verify() with changed inputs reported no stale files")
+	}
+	if !strings.Contains(err.Error(), "stale generated file(s)") {
+		t.Errorf("Verify() error does not list stale files: %v", err)
+	}
+}
+
+// TestProguardRulesMatchGeneratedClasses checks that the keep rules target
+// classes the generator actually emits: the operation classes carry the
+// @Serializable annotation the `-if` condition matches on, and the rules
+// cover both the base package and a @ktPackage override outside it.
+func TestProguardRulesMatchGeneratedClasses(t *testing.T) {
+	s, operations := loadTestInputs(t, testSchemaGQL, `
+query ListMovies {
+  movies { id title releaseYear }
+}
+
+query GetMovie($key: Movie_Key!) @ktPackage(name: "com.example.elsewhere") {
+  movie(key: $key) { id title releaseYear }
+}
+`)
+	destDir := t.TempDir()
+	generator := &Generator{
+		Package:       "com.example.test",
+		Connector:     "test-connector",
+		DestDir:       destDir,
+		ProguardRules: true,
+	}
+	if err := generator.Generate(s, operations); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	operation, err := os.ReadFile(filepath.Join(destDir, "com", "example", "test", "ListMoviesQuery.kt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(operation, []byte("@kotlinx.serialization.Serializable")) {
+		t.Errorf("generated operation classes carry no @Serializable annotation:\n%s", operation)
+	}
+	rules, err := os.ReadFile(filepath.Join(destDir, "test-connector-proguard-rules.pro"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range []string{"com.example.test", "com.example.elsewhere"} {
+		if !bytes.Contains(rules, []byte("-if @kotlinx.serialization.Serializable class "+pkg+".**")) {
+			t.Errorf("keep rules do not cover %s:\n%s", pkg, rules)
+		}
+	}
+}
+
+// TestProtoConverters checks that DataStoreProto pairs the .proto schema
+// with Kotlin converters: a response class selecting only message-mappable
+// columns gets a toProto extension and a from-proto extension that restores
+// nullable columns through the message's has-accessor.
+func TestProtoConverters(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	destDir := t.TempDir()
+	generator := &Generator{
+		Package:        "com.example.test",
+		Connector:      "test-connector",
+		DestDir:        destDir,
+		DataStoreProto: true,
+	}
+	if err := generator.Generate(s, operations); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	contents, err := os.ReadFile(
+		filepath.Join(destDir, "com", "example", "test", "TestConnectorProtoConverters.kt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"public fun ListMoviesQuery.Data.MoviesItem.toProto(): com.example.test.proto.Movie {",
+		"public fun com.example.test.proto.Movie.toListMoviesQueryDataMoviesItem(): ListMoviesQuery.Data.MoviesItem = ",
+		"releaseYear?.let { builder.setReleaseYear(it) }",
+		"releaseYear = if (hasReleaseYear()) getReleaseYear() else null,",
+	} {
+		if !bytes.Contains(contents, []byte(want)) {
+			t.Errorf("converters file lacks %q:\n%s", want, contents)
+		}
+	}
+}
+
+// TestOperationTemplateOverride checks that an operation.gotmpl in the
+// template directory replaces the built-in rendering of each operation's
+// file, while other outputs keep the built-in renderer.
+func TestOperationTemplateOverride(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	templateDir := t.TempDir()
+	const tmpl = `{{/* dataconnect:template-version=1 */}}package {{.Package}}
+
+// custom rendering of {{.Name}} ({{.Kind}})
+public object {{.ClassName}}
+`
+	if err := os.WriteFile(filepath.Join(templateDir, "operation.gotmpl"), []byte(tmpl), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	destDir := t.TempDir()
+	generator := &Generator{
+		Package:     "com.example.test",
+		Connector:   "test-connector",
+		DestDir:     destDir,
+		TemplateDir: templateDir,
+	}
+	if err := generator.Generate(s, operations); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	// Operation files are named after the kind-suffixed class, not the
+	// bare operation name.
+	contents, err := os.ReadFile(filepath.Join(destDir, "com", "example", "test", "ListMoviesQuery.kt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(contents, []byte("custom rendering of ListMovies (query)")) {
+		t.Errorf("ListMoviesQuery.kt was not rendered by the override template:\n%s", contents)
+	}
+}
+
+// TestGenerateIsReproducible generates the same inputs twice and requires
+// byte-identical outputs: no timestamps, no unstable hashes, no dependence
+// on map iteration order.
+func TestGenerateIsReproducible(t *testing.T) {
+	s, operations := writeTestInputs(t)
+	var outputs [2]map[string][]byte
+	for i := range outputs {
+		destDir := t.TempDir()
+		generator := &Generator{
+			Package:       "com.example.test",
+			Connector:     "test-connector",
+			DestDir:       destDir,
+			InputChecksum: schema.Checksum(s, operations, "", ""),
+		}
+		if err := generator.Generate(s, operations); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		outputs[i] = readTree(t, destDir)
+	}
+	if len(outputs[0]) == 0 {
+		t.Fatal("Generate() produced no files")
+	}
+	for name, contents := range outputs[0] {
+		other, ok := outputs[1][name]
+		if !ok {
+			t.Errorf("second run did not produce %s", name)
+			continue
+		}
+		if !bytes.Equal(contents, other) {
+			t.Errorf("%s differs between runs", name)
+		}
+	}
+}
+
+// readTree reads every file beneath dir, keyed by relative path.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[relative] = contents
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// grpcMethods maps an operation kind to the ConnectorService method that
+// executes it.
+var grpcMethods = map[ast.Operation]string{
+	ast.Query:    "google.firebase.dataconnect.v1.ConnectorService/ExecuteQuery",
+	ast.Mutation: "google.firebase.dataconnect.v1.ConnectorService/ExecuteMutation",
+}
+
+// renderGrpcStub writes a thin typed wrapper over the low-level
+// ExecuteQuery/ExecuteMutation surface, for advanced users bypassing the
+// high-level SDK. The stub pre-binds the gRPC method, operation name and
+// text, and response decoding; the caller supplies only the transport.
+func renderGrpcStub(w *writer, m *operationModel) {
+	w.line("package %s", m.Package)
+	w.line("")
+	w.line("public object %sGrpcStub {", m.ClassName)
+	w.in()
+	w.line("/** The fully qualified gRPC method executing this operation. */")
+	w.line("public const val methodName: String =")
+	w.in()
+	w.line("%q", grpcMethods[m.Kind])
+	w.out()
+	w.line("")
+	w.line("/**")
+	w.line(" * Executes %s over [transport], which is handed the operation's", m.Name)
+	w.line(" * name and text and returns the response data as JSON.")
+	w.line(" */")
+	w.line("public suspend fun execute(")
+	w.in()
+	if m.Variables != nil {
+		w.line("variables: %s.Variables,", m.ClassName)
+		w.line("transport: suspend (operationName: String, operationSource: String, variables: %s.Variables) -> String,",
+			m.ClassName)
+	} else {
+		w.line("transport: suspend (operationName: String, operationSource: String) -> String,")
+	}
+	w.out()
+	w.line("): %s.Data {", m.ClassName)
+	w.in()
+	if m.Variables != nil {
+		w.line("val response = transport(%s.operationName, %s.operationSource, variables)", m.ClassName, m.ClassName)
+	} else {
+		w.line("val response = transport(%s.operationName, %s.operationSource)", m.ClassName, m.ClassName)
+	}
+	// The member overload with an explicit serializer is used because the
+	// one-argument decodeFromString extension does not resolve through a
+	// fully qualified receiver, and generated code carries no imports.
+	w.line("return kotlinx.serialization.json.Json.decodeFromString(")
+	w.in()
+	w.line("kotlinx.serialization.serializer<%s.Data>(), response)", m.ClassName)
+	w.out()
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// generateValueClasses writes Ids.kt, declaring an inline value class per
+// table whose key is a single ID-like scalar, so that keys of different
+// tables cannot be mixed up at compile time.
+func (g *Generator) generateValueClasses(s *schema.Schema) error {
+	var tables []*ast.Definition
+	for _, table := range s.Tables {
+		keyFields, err := s.KeyFields(table)
+		if err != nil {
+			return err
+		}
+		if len(keyFields) == 1 && isIDScalar(keyFields[0].Type) {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	return g.writeFile("Ids.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, table := range tables {
+			w.line("")
+			w.line("/** The key of a row of the %s table. */", table.Name)
+			if g.Serialization == "" {
+				w.line("@kotlinx.serialization.Serializable")
+			}
+			w.line("@JvmInline")
+			w.line("public value class %sId(public val value: String)", table.Name)
+		}
+	})
+}
+
+// isIDScalar reports whether a type reference is a non-list ID-like scalar.
+func isIDScalar(t *ast.Type) bool {
+	return t.Elem == nil && (t.NamedType == "ID" || t.NamedType == "UUID")
+}
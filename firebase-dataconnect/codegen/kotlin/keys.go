@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// typedKeyName maps a synthesized <Table>_Key input type to the shared
+// typed key class under TypedKeys, e.g. Movie_Key to MovieKey. It returns
+// false when TypedKeys is off or the type is not a table's key.
+func (g *Generator) typedKeyName(s *schema.Schema, def *ast.Definition) (string, bool) {
+	if !g.TypedKeys || def == nil {
+		return "", false
+	}
+	table, ok := strings.CutSuffix(def.Name, "_Key")
+	if !ok {
+		return "", false
+	}
+	tableDef := s.Types[table]
+	if tableDef == nil || tableDef.Directives.ForName("table") == nil {
+		return "", false
+	}
+	return upperFirst(table) + "Key", true
+}
+
+// generateKeyClasses writes Keys.kt, declaring one shared key class per
+// table. Query-by-key variables and the key selections returned by insert,
+// update, and delete mutations all use the shared class, so a key returned
+// by one operation can be passed to another directly.
+func (g *Generator) generateKeyClasses(s *schema.Schema) error {
+	if len(s.Tables) == 0 {
+		return nil
+	}
+	return g.writeFile("Keys.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, table := range s.Tables {
+			keyType := s.Types[table.Name+"_Key"]
+			if keyType == nil {
+				continue
+			}
+			w.line("")
+			w.line("/** The key identifying a row of the %s table. */", table.Name)
+			switch g.Serialization {
+			case "":
+				w.line("@kotlinx.serialization.Serializable")
+			case "moshi":
+				w.line("@com.squareup.moshi.JsonClass(generateAdapter = true)")
+			}
+			w.line("public data class %sKey(", upperFirst(table.Name))
+			w.in()
+			for _, field := range keyType.Fields {
+				w.line("val %s: %s,", propertyName(field.Name), g.scalarType(field.Type.NamedType))
+			}
+			w.out()
+			w.line(")")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// usesLatLng reports whether any table carries a LatLng field, which is
+// when the generated sources reference the LatLng class.
+func usesLatLng(s *schema.Schema) bool {
+	for _, table := range s.Tables {
+		for _, field := range table.Fields {
+			if field.Type.Name() == "LatLng" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderLatLng writes the LatLng class the LatLng scalar maps to, with its
+// serializer for the {latitude, longitude} wire object. One copy is written
+// per generated package whenever the schema declares the scalar, so
+// variables and data classes can use the type directly instead of its
+// serialized string form.
+func renderLatLng(w *writer, kotlinPackage string) {
+	w.line("package %s", kotlinPackage)
+	w.line("")
+	w.line("/** A geographic point, as carried by the LatLng scalar. */")
+	w.line("@kotlinx.serialization.Serializable(with = LatLngSerializer::class)")
+	w.line("public data class LatLng(")
+	w.in()
+	w.line("val latitude: Double,")
+	w.line("val longitude: Double,")
+	w.out()
+	w.line(")")
+	w.line("")
+	w.line("/** Serializes [LatLng] as its `{latitude, longitude}` wire object. */")
+	w.line("public object LatLngSerializer : kotlinx.serialization.KSerializer<LatLng> {")
+	w.in()
+	w.line("@kotlinx.serialization.Serializable")
+	w.line("private data class Surrogate(val latitude: Double, val longitude: Double)")
+	w.line("")
+	w.line("override val descriptor: kotlinx.serialization.descriptors.SerialDescriptor =")
+	w.in()
+	w.line("Surrogate.serializer().descriptor")
+	w.out()
+	w.line("")
+	w.line("override fun serialize(encoder: kotlinx.serialization.encoding.Encoder, value: LatLng) {")
+	w.in()
+	w.line("encoder.encodeSerializableValue(Surrogate.serializer(), Surrogate(value.latitude, value.longitude))")
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("override fun deserialize(decoder: kotlinx.serialization.encoding.Decoder): LatLng {")
+	w.in()
+	w.line("val surrogate = decoder.decodeSerializableValue(Surrogate.serializer())")
+	w.line("return LatLng(surrogate.latitude, surrogate.longitude)")
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
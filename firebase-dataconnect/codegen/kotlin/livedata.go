@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// renderLiveDataWrapper writes an asLiveData extension for a query, turning
+// a suspending executor for the operation into a LiveData that emits the
+// result once observed.
+func renderLiveDataWrapper(w *writer, m *operationModel) {
+	dataType := m.ClassName + ".Data"
+	w.line("package %s", m.Package)
+	w.line("")
+	w.line("/** Runs [execute] once an observer is attached and emits its result. */")
+	w.line("public fun %s.asLiveData(", m.ClassName)
+	w.in()
+	w.line("execute: suspend () -> %s,", dataType)
+	w.out()
+	w.line("): androidx.lifecycle.LiveData<%s> =", dataType)
+	w.in()
+	w.line("androidx.lifecycle.liveData { emit(execute()) }")
+	w.out()
+}
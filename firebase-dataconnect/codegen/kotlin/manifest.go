@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// manifestName is the file recording each connector's input and output
+// hashes for incremental mode, written beneath DestDir. Like the connector
+// registry, one file serves every connector generating into the dest dir,
+// keyed by connector name.
+const manifestName = ".codegen-manifest.json"
+
+// manifest records what one run consumed and produced: the hash of each
+// input (the combined schema and operations, plus any template hook files)
+// and the hash of each output file, keyed relative to DestDir. A later run
+// whose inputs hash the same can skip rewriting outputs that still match,
+// keeping their timestamps stable for Gradle's build caching.
+type manifest struct {
+	Inputs  map[string]string `json:"inputs"`
+	Outputs map[string]string `json:"outputs"`
+}
+
+// manifestTracker carries the previous run's manifest and accumulates the
+// current one. Operation workers record outputs concurrently, so the maps
+// are guarded rather than written directly.
+type manifestTracker struct {
+	mu      sync.Mutex
+	current manifest
+
+	// previous is the last run's manifest for this connector, and unchanged
+	// reports whether the current inputs hash the same as its inputs.
+	previous  manifest
+	unchanged bool
+
+	// skipped and pruned count outputs left untouched and stale outputs
+	// removed, for end-of-run reporting.
+	skipped int
+	pruned  int
+}
+
+// manifestInputs hashes everything the generated output depends on: the
+// schema and operations through their combined checksum, and each template
+// hook file by contents.
+func (g *Generator) manifestInputs() map[string]string {
+	inputs := map[string]string{"schema+operations": g.InputChecksum}
+	if g.TemplateHookDir != "" {
+		for _, name := range []string{"header.gotmpl", "footer.gotmpl"} {
+			contents, err := os.ReadFile(filepath.Join(g.TemplateHookDir, name))
+			if err != nil {
+				continue
+			}
+			inputs["template:"+name] = schema.DocumentChecksum(string(contents))
+		}
+	}
+	if g.TemplateDir != "" {
+		contents, err := os.ReadFile(filepath.Join(g.TemplateDir, operationTemplateName))
+		if err == nil {
+			inputs["override:"+operationTemplateName] = schema.DocumentChecksum(string(contents))
+		}
+	}
+	return inputs
+}
+
+// loadManifestTracker reads the dest dir's manifest and prepares the
+// tracker for this connector's run. A missing or corrupt manifest simply
+// disables skipping; it never fails the run.
+func (g *Generator) loadManifestTracker() *manifestTracker {
+	tracker := &manifestTracker{current: manifest{
+		Inputs:  g.manifestInputs(),
+		Outputs: map[string]string{},
+	}}
+	contents, err := os.ReadFile(filepath.Join(g.DestDir, manifestName))
+	if err != nil {
+		return tracker
+	}
+	var previous map[string]manifest
+	if err := json.Unmarshal(contents, &previous); err != nil {
+		return tracker
+	}
+	tracker.previous = previous[g.Connector]
+	tracker.unchanged = len(tracker.previous.Inputs) > 0 &&
+		equalStringMaps(tracker.previous.Inputs, tracker.current.Inputs)
+	return tracker
+}
+
+// saveManifest merges this connector's section into the dest dir's
+// manifest and removes outputs the previous run produced but this one did
+// not, so renamed operations do not leave stale files behind.
+func (g *Generator) saveManifest(tracker *manifestTracker) error {
+	for rel := range tracker.previous.Outputs {
+		if _, ok := tracker.current.Outputs[rel]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(g.DestDir, filepath.FromSlash(rel))); err == nil {
+			tracker.pruned++
+		}
+	}
+	path := filepath.Join(g.DestDir, manifestName)
+	merged := map[string]manifest{}
+	if contents, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(contents, &merged)
+	}
+	merged[g.Connector] = tracker.current
+	contents, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o666)
+}
+
+// shouldSkip reports whether the output at path can be left untouched:
+// the inputs are unchanged and the file on disk still hashes to what the
+// previous run recorded. A skipped file is carried into the current
+// manifest as-is.
+func (t *manifestTracker) shouldSkip(rel, path string) bool {
+	if t == nil || !t.unchanged {
+		return false
+	}
+	recorded, ok := t.previous.Outputs[rel]
+	if !ok {
+		return false
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil || schema.DocumentChecksum(string(contents)) != recorded {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current.Outputs[rel] = recorded
+	t.skipped++
+	return true
+}
+
+// record notes the hash of a freshly written output.
+func (t *manifestTracker) record(rel, hash string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current.Outputs[rel] = hash
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if b[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+)
+
+// mapperTarget is a table's <Table>_Data input class as nested by some
+// mutation, with the fully qualified Kotlin name it is reachable under.
+type mapperTarget struct {
+	Qualified string
+	Class     *classModel
+}
+
+// dataMapper is one generated conversion: an extension function on a
+// response class rebuilding the table's input class from the selected
+// fields.
+type dataMapper struct {
+	Receiver string
+	Table    string
+	Source   *classModel
+	Target   mapperTarget
+}
+
+// generateDataMappers writes <Connector>Mappers.kt: for every response
+// class that selects fields of a table whose <Table>_Data class some
+// mutation nests, an extension function converting the response back into
+// the input class. Fetched rows can then be echoed into update or insert
+// variables without hand-written field copying.
+func (g *Generator) generateDataMappers(s *schema.Schema, models []*operationModel) error {
+	targets := map[string]mapperTarget{}
+	for _, model := range models {
+		if model.Variables == nil {
+			continue
+		}
+		for _, nested := range model.Variables.Nested {
+			table, ok := strings.CutSuffix(nested.Name, "_Data")
+			if !ok || s.Types[table] == nil {
+				continue
+			}
+			if _, seen := targets[table]; seen {
+				continue
+			}
+			// The mapper file lives in g.Package, but under per-kind
+			// sharding or a @ktPackage override the operation's classes do
+			// not; qualify them with their own package where it differs.
+			targets[table] = mapperTarget{
+				Qualified: g.qualifiedOperation(model) + ".Variables." + nested.Name,
+				Class:     nested,
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	var mappers []dataMapper
+	for _, model := range models {
+		if model.Data == nil {
+			continue
+		}
+		collectDataMappers(g.qualifiedOperation(model)+"."+model.Data.Name, model.Data, targets, &mappers)
+	}
+	if len(mappers) == 0 {
+		return nil
+	}
+	return g.writeFile(upperCamel(g.Connector)+"Mappers.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, mapper := range mappers {
+			w.line("")
+			w.line("/** Rebuilds a [%s] from the fields selected into [%s]. */",
+				mapper.Target.Qualified, mapper.Receiver)
+			w.line("public fun %s.to%sData(): %s = %s(",
+				mapper.Receiver, upperFirst(mapper.Table), mapper.Target.Qualified, mapper.Target.Qualified)
+			w.in()
+			for _, field := range mapper.Target.Class.Fields {
+				if source := mapper.Source.field(field.Name); source != nil {
+					w.line("%s = %s,", field.property(), source.property())
+				}
+			}
+			w.out()
+			w.line(")")
+		}
+	})
+}
+
+// collectDataMappers walks a response class tree and records every class
+// whose selections cover a mappable table, in declaration order.
+func collectDataMappers(qualified string, c *classModel, targets map[string]mapperTarget, mappers *[]dataMapper) {
+	if table := selectionTable(c); table != "" {
+		if target, ok := targets[table]; ok && coversInputFields(target.Class, c) {
+			*mappers = append(*mappers, dataMapper{
+				Receiver: qualified,
+				Table:    table,
+				Source:   c,
+				Target:   target,
+			})
+		}
+	}
+	for _, nested := range c.Nested {
+		collectDataMappers(qualified+"."+nested.Name, nested, targets, mappers)
+	}
+}
+
+// selectionTable returns the type all of a response class's selections were
+// made on, or "" when the class has none or they span several types.
+func selectionTable(c *classModel) string {
+	name := ""
+	for _, field := range c.Fields {
+		if field.Selection == nil || field.Selection.ObjectDefinition == nil {
+			return ""
+		}
+		switch name {
+		case "", field.Selection.ObjectDefinition.Name:
+			name = field.Selection.ObjectDefinition.Name
+		default:
+			return ""
+		}
+	}
+	return name
+}
+
+// coversInputFields reports whether a response class selects enough to
+// construct the input class: every input field is either selected with a
+// compatible type or defaulted, so the conversion never needs a value the
+// query did not fetch.
+func coversInputFields(target, source *classModel) bool {
+	for _, field := range target.Fields {
+		selected := source.field(field.Name)
+		if selected == nil {
+			if !field.HasDefault {
+				return false
+			}
+			continue
+		}
+		if selected.Type != field.Type && selected.Type+"?" != field.Type {
+			return false
+		}
+	}
+	return len(target.Fields) > 0
+}
@@ -0,0 +1,726 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// operationModel is everything the renderer needs to emit the Kotlin file
+// for one operation.
+type operationModel struct {
+	// Name is the operation name as declared in GraphQL; ClassName is the
+	// name of the generated Kotlin object, e.g. "CreateMovieMutation".
+	Name      string
+	ClassName string
+	Kind      ast.Operation
+	Package   string
+	Source    string
+
+	// PackageOverridden marks operations whose Package comes from a
+	// @ktPackage directive rather than the Generator setting; such
+	// operations are exempt from per-kind sharding.
+	PackageOverridden bool
+
+	// Doc is the comment block preceding the operation in its .gql file,
+	// carried through as KDoc on the generated object.
+	Doc string
+
+	// Serialization is the Generator's serialization library setting.
+	Serialization string
+
+	// AuthLevel is the @auth(level:) the operation declares, empty when it
+	// declares none. AuthGuard additionally generates a checkAuth
+	// precondition from it.
+	AuthLevel string
+	AuthGuard bool
+
+	// ResourcePath, when set, is the classpath location the operation's
+	// GraphQL text was written to; operationSource then reads the resource
+	// instead of embedding the text.
+	ResourcePath string
+
+	// Options are per-operation overrides parsed from a "# codegen:"
+	// comment line preceding the operation, overriding the corresponding
+	// Generator flags for this operation only.
+	Options operationOptions
+
+	// Variables is nil when the operation declares no variables.
+	Variables *classModel
+	Data      *classModel
+
+	// Diff is the diff(key, old, new) helper generated for mutations in
+	// the canonical update shape under DiffHelpers, or nil.
+	Diff *diffModel
+}
+
+// classModel is a generated Kotlin data class.
+type classModel struct {
+	Name         string
+	Doc          string
+	Fields       []*fieldModel
+	Nested       []*classModel
+	Constructors []*constructorModel
+}
+
+// nested returns the class's nested class with the given name, or nil.
+func (c *classModel) nested(name string) *classModel {
+	for _, n := range c.Nested {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// anyRedacted reports whether any of the class's fields is redacted.
+func (c *classModel) anyRedacted() bool {
+	for _, f := range c.Fields {
+		if f.Redacted {
+			return true
+		}
+	}
+	return false
+}
+
+// anyByteArray reports whether any of the class's fields is a ByteArray.
+func (c *classModel) anyByteArray() bool {
+	for _, f := range c.Fields {
+		if isByteArray(f.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// field returns the class's field with the given name, or nil.
+func (c *classModel) field(name string) *fieldModel {
+	for _, f := range c.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// fieldModel is one property of a generated data class.
+type fieldModel struct {
+	Name string
+	// Type is the Kotlin type, including a trailing '?' when nullable.
+	Type string
+	Doc  string
+
+	// KotlinName is the property name a @ktName directive assigns, or ""
+	// to derive the property name from the GraphQL name.
+	KotlinName string
+
+	// Redacted marks fields declared @redacted in the schema; their values
+	// are masked in the generated class's toString().
+	Redacted bool
+
+	// ForcedNonNull marks response fields generated non-null by @ktNonNull
+	// despite a nullable schema type; decoding a null for them fails.
+	ForcedNonNull bool
+
+	// HasDefault marks fields the server populates when they are omitted;
+	// they are generated with a null default. ServerExpr additionally holds
+	// the server-value expression from @default(expr:), if that is how the
+	// default is produced; such fields are left out of flattening
+	// constructors entirely, since callers never supply them.
+	HasDefault bool
+	ServerExpr string
+
+	// Selection is the response selection the field came from, or nil for
+	// variables-class fields. It lets later selections of the same alias be
+	// merged or reported as conflicts.
+	Selection *ast.Field
+}
+
+// property returns the Kotlin property name for the field: the @ktName
+// override when one is given, otherwise the escaped GraphQL name.
+func (f *fieldModel) property() string {
+	if f.KotlinName != "" {
+		return f.KotlinName
+	}
+	return propertyName(f.Name)
+}
+
+// ktNameOf returns the Kotlin name a @ktName directive assigns, or "".
+func ktNameOf(directives ast.DirectiveList) string {
+	if directive := directives.ForName("ktName"); directive != nil {
+		if arg := directive.Arguments.ForName("name"); arg != nil {
+			return arg.Value.Raw
+		}
+	}
+	return ""
+}
+
+// constructorModel is a secondary constructor that flattens the fields of a
+// single input-object variable into direct parameters.
+type constructorModel struct {
+	// Field is the Variables property the constructor populates, and Class
+	// is the nested class being flattened into parameters.
+	Field *fieldModel
+	Class *classModel
+
+	// Vararg marks a constructor for a list-of-input-object variable, which
+	// takes the elements as a vararg rather than flattening their fields.
+	Vararg bool
+
+	// Builder marks a named companion builder function generated in place
+	// of a flattening constructor under the Builders mode.
+	Builder bool
+
+	// Nested holds the sub-constructors for input-object fields flattened
+	// in place within the configured flatten depth, keyed by field name.
+	// Fields not in the map are passed as objects.
+	Nested map[string]*constructorModel
+
+	// Prefix is prepended to this level's parameter names, camel-cased, so
+	// that flattened nested fields cannot collide with outer ones. It is
+	// empty at the top level.
+	Prefix string
+}
+
+// paramName returns the constructor parameter name for one of the level's
+// fields: the field's property name, prefixed by the flattened path when
+// the field comes from a nested level.
+func (c *constructorModel) paramName(f *fieldModel) string {
+	if c.Prefix == "" {
+		return f.property()
+	}
+	return c.Prefix + upperFirst(f.property())
+}
+
+func (g *Generator) operationModel(s *schema.Schema, op *schema.Operation) (*operationModel, error) {
+	options, doc, err := parseOperationOptions(op.Doc)
+	if err != nil {
+		return nil, fmt.Errorf("operation %s: %w", op.Name, err)
+	}
+	model := &operationModel{
+		Name:          op.Name,
+		Kind:          op.Operation,
+		Package:       g.Package,
+		Source:        op.Source,
+		Doc:           doc,
+		Serialization: g.Serialization,
+		Options:       options,
+	}
+	if serialization, ok := options["serialization"]; ok {
+		model.Serialization = serialization
+	}
+	model.AuthGuard = model.boolOption("authGuards", g.AuthGuards)
+	if directive := op.Directives.ForName("auth"); directive != nil {
+		if arg := directive.Arguments.ForName("level"); arg != nil {
+			model.AuthLevel = arg.Value.Raw
+		}
+	}
+	switch op.Operation {
+	case ast.Query:
+		model.ClassName = upperFirst(op.Name) + "Query"
+	case ast.Mutation:
+		model.ClassName = upperFirst(op.Name) + "Mutation"
+	default:
+		return nil, fmt.Errorf("operation %s: unsupported operation type %q", op.Name, op.Operation)
+	}
+	if name := ktNameOf(op.Directives); name != "" {
+		model.ClassName = name
+	}
+	if directive := op.Directives.ForName("ktPackage"); directive != nil {
+		if arg := directive.Arguments.ForName("name"); arg != nil {
+			model.Package = arg.Value.Raw
+			model.PackageOverridden = true
+		}
+	}
+
+	variables, err := g.variablesClass(s, op)
+	if err != nil {
+		return nil, fmt.Errorf("operation %s: %w", op.Name, err)
+	}
+	model.Variables = variables
+	if g.DiffHelpers && op.Operation == ast.Mutation {
+		model.Diff = diffFor(variables)
+	}
+
+	root := s.Query
+	if op.Operation == ast.Mutation {
+		root = s.Mutation
+	}
+	data, err := g.selectionClass(s, "Data", root, op.SelectionSet, 1)
+	if err != nil {
+		return nil, fmt.Errorf("operation %s: %w", op.Name, err)
+	}
+	model.Data = data
+	return model, nil
+}
+
+// variablesClass builds the Variables class from the operation's variable
+// definitions, synthesizing a nested class for each input-object type that
+// is referenced.
+func (g *Generator) variablesClass(s *schema.Schema, op *schema.Operation) (*classModel, error) {
+	if len(op.VariableDefinitions) == 0 {
+		return nil, nil
+	}
+	class := &classModel{Name: "Variables"}
+	inputClasses := map[string]*classModel{}
+	for _, variable := range op.VariableDefinitions {
+		kotlinType, err := g.variableType(s, variable.Type, class, inputClasses, 1)
+		if err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", variable.Variable, err)
+		}
+		class.Fields = append(class.Fields, &fieldModel{
+			Name:       variable.Variable,
+			Type:       kotlinType,
+			KotlinName: ktNameOf(variable.Directives),
+		})
+	}
+
+	if g.NoConvenienceConstructors {
+		return class, nil
+	}
+
+	// Under Builders, each input-object variable gets a named companion
+	// builder function instead of a constructor overload. Naming the
+	// function after the variable means two input types with identical
+	// scalar signatures cannot produce ambiguous overloads.
+	if g.Builders {
+		for _, field := range class.Fields {
+			baseType := trimNullability(field.Type)
+			if _, isList := listElementType(baseType); isList {
+				continue
+			}
+			flattened := inputClasses[baseType]
+			if flattened == nil {
+				continue
+			}
+			constructor := &constructorModel{
+				Field:   field,
+				Class:   flattened,
+				Builder: true,
+			}
+			g.flattenNested(constructor, inputClasses, 1)
+			if count := flattenedParamCount(constructor); count > g.maxFlattenedParams() {
+				return nil, fmt.Errorf(
+					"flattening $%s would generate a builder with %d parameters, more than the limit of %d; raise -max_constructor_params if this is intentional",
+					field.Name, count, g.maxFlattenedParams())
+			}
+			class.Constructors = append(class.Constructors, constructor)
+		}
+		return class, nil
+	}
+
+	// The common shape of a mutation is a single input-object variable, e.g.
+	// ($data: Movie_Data!). Generate a constructor that flattens its fields
+	// into direct parameters. Fields the server populates from an expression
+	// are omitted: the caller has nothing meaningful to pass for them. For a
+	// list-of-input-object variable, as used by bulk inserts, generate a
+	// vararg constructor instead.
+	if len(class.Fields) == 1 {
+		field := class.Fields[0]
+		baseType := trimNullability(field.Type)
+		if element, isList := listElementType(baseType); isList {
+			if elementClass := inputClasses[trimNullability(element)]; elementClass != nil {
+				class.Constructors = append(class.Constructors, &constructorModel{
+					Field:  field,
+					Class:  elementClass,
+					Vararg: true,
+				})
+			}
+		} else if flattened := inputClasses[baseType]; flattened != nil {
+			constructor := &constructorModel{
+				Field: field,
+				Class: flattened,
+			}
+			g.flattenNested(constructor, inputClasses, 1)
+			if count := flattenedParamCount(constructor); count > g.maxFlattenedParams() {
+				return nil, fmt.Errorf(
+					"flattening $%s would generate a constructor with %d parameters, more than the limit of %d; raise -max_constructor_params if this is intentional",
+					field.Name, count, g.maxFlattenedParams())
+			}
+			class.Constructors = append(class.Constructors, constructor)
+		}
+	}
+	return class, nil
+}
+
+// flattenNested fills in sub-constructors for input-object fields within
+// the configured flatten depth; their scalar fields become direct parameters
+// in place of the object. Nullable objects and lists are always passed
+// whole, since flattening a nullable object would lose the ability to pass
+// null for it.
+func (g *Generator) flattenNested(c *constructorModel, inputClasses map[string]*classModel, depth int) {
+	if depth >= g.flattenDepth() {
+		return
+	}
+	for _, field := range c.Class.Fields {
+		if field.ServerExpr != "" || strings.HasSuffix(field.Type, "?") {
+			continue
+		}
+		nested := inputClasses[field.Type]
+		if nested == nil {
+			continue
+		}
+		sub := &constructorModel{
+			Field:  field,
+			Class:  nested,
+			Prefix: c.paramName(field),
+		}
+		g.flattenNested(sub, inputClasses, depth+1)
+		if c.Nested == nil {
+			c.Nested = map[string]*constructorModel{}
+		}
+		c.Nested[field.Name] = sub
+	}
+}
+
+// flattenedParamCount counts the parameters a flattening constructor takes:
+// every field except those the server populates from an expression,
+// recursing through the levels flattened in place.
+func flattenedParamCount(c *constructorModel) int {
+	count := 0
+	for _, field := range c.Class.Fields {
+		if field.ServerExpr != "" {
+			continue
+		}
+		if sub := c.Nested[field.Name]; sub != nil {
+			count += flattenedParamCount(sub)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// listElementType returns the element type of a Kotlin List type, e.g.
+// "Movie_Data" for "List<Movie_Data>".
+func listElementType(kotlinType string) (string, bool) {
+	if strings.HasPrefix(kotlinType, "List<") && strings.HasSuffix(kotlinType, ">") {
+		return kotlinType[len("List<") : len(kotlinType)-1], true
+	}
+	return "", false
+}
+
+// variableType resolves a variable's GraphQL type to a Kotlin type,
+// synthesizing nested classes for input objects as needed.
+func (g *Generator) variableType(s *schema.Schema, t *ast.Type, class *classModel,
+	inputClasses map[string]*classModel, depth int) (string, error) {
+	if t.Elem != nil {
+		elem, err := g.variableType(s, t.Elem, class, inputClasses, depth)
+		if err != nil {
+			return "", err
+		}
+		name := "List<" + elem + ">"
+		if !t.NonNull {
+			name += "?"
+		}
+		return name, nil
+	}
+	def := s.Types[t.NamedType]
+	var name string
+	switch {
+	case def == nil || def.Kind == ast.Scalar:
+		name = g.scalarType(t.NamedType)
+	case def.Kind == ast.Enum:
+		name = def.Name
+		g.noteEnum(def)
+	case def.Kind == ast.InputObject:
+		if keyName, ok := g.typedKeyName(s, def); ok {
+			name = keyName
+			break
+		}
+		name = className(def.Name)
+		if inputClasses[name] == nil {
+			if _, err := g.inputClass(s, def, class, inputClasses, depth); err != nil {
+				return "", err
+			}
+		}
+	default:
+		return "", fmt.Errorf("%s types are not supported in variables", def.Kind)
+	}
+	if !t.NonNull {
+		name += "?"
+	}
+	return name, nil
+}
+
+// inputClass builds the nested class for an input-object type referenced
+// from Variables.
+func (g *Generator) inputClass(s *schema.Schema, def *ast.Definition, class *classModel,
+	inputClasses map[string]*classModel, depth int) (*classModel, error) {
+	if depth > g.maxDepth() {
+		return nil, fmt.Errorf("%s: input types nest more than %d levels deep; raise -max_depth if this is intentional",
+			def.Name, g.maxDepth())
+	}
+	input := &classModel{Name: className(def.Name), Doc: def.Description}
+	inputClasses[input.Name] = input
+	class.Nested = append(class.Nested, input)
+	for _, field := range def.Fields {
+		kotlinType, err := g.variableType(s, field.Type, class, inputClasses, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", def.Name, field.Name, err)
+		}
+		if g.ValueClasses && len(def.Fields) == 1 && isIDScalar(field.Type) {
+			if table, isKey := strings.CutSuffix(def.Name, "_Key"); isKey {
+				kotlinType = upperFirst(table) + "Id"
+			}
+		}
+		model := &fieldModel{
+			Name:       field.Name,
+			Type:       kotlinType,
+			Doc:        field.Description,
+			KotlinName: ktNameOf(field.Directives),
+			Redacted:   field.Directives.ForName("redacted") != nil,
+		}
+		if directive := field.Directives.ForName("default"); directive != nil {
+			model.HasDefault = true
+			if arg := directive.Arguments.ForName("expr"); arg != nil {
+				model.ServerExpr = arg.Value.Raw
+				model.Doc = fmt.Sprintf(
+					"Populated by the server from the expression `%s` when not set.", model.ServerExpr)
+			}
+		}
+		input.Fields = append(input.Fields, model)
+	}
+	return input, nil
+}
+
+// selectionClass builds a data class from a selection set, recursing into
+// composite fields. parent is the type the selections are made on.
+func (g *Generator) selectionClass(s *schema.Schema, name string, parent *ast.Definition,
+	selections ast.SelectionSet, depth int) (*classModel, error) {
+	if depth > g.maxDepth() {
+		return nil, fmt.Errorf("selection sets nest more than %d levels deep; raise -max_depth if this is intentional",
+			g.maxDepth())
+	}
+	class := &classModel{Name: name}
+	if err := g.addSelections(s, class, parent, selections, depth); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// addSelections adds the fields of a selection set to a class. Fragments
+// whose type condition matches the parent type, or is an interface or union
+// the parent belongs to, are flattened into the class; fragments narrowing
+// an abstract parent to a concrete type become a nullable as<Type> section.
+func (g *Generator) addSelections(s *schema.Schema, class *classModel, parent *ast.Definition,
+	selections ast.SelectionSet, depth int) error {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if existing := class.field(sel.Alias); existing != nil {
+				if err := g.mergeDuplicateField(s, class, existing, sel, depth); err != nil {
+					return err
+				}
+				continue
+			}
+			kotlinType, err := g.responseFieldType(s, sel, sel.Definition.Type, class, false, depth)
+			if err != nil {
+				return err
+			}
+			kotlinName := ktNameOf(sel.Directives)
+			if kotlinName == "" {
+				kotlinName = ktNameOf(sel.Definition.Directives)
+			}
+			forcedNonNull := false
+			if sel.Directives.ForName("ktNonNull") != nil || sel.Definition.Directives.ForName("ktNonNull") != nil {
+				if trimmed := strings.TrimSuffix(kotlinType, "?"); trimmed != kotlinType {
+					kotlinType = trimmed
+					forcedNonNull = true
+				}
+			}
+			class.Fields = append(class.Fields, &fieldModel{
+				Name:          sel.Alias,
+				Type:          kotlinType,
+				Doc:           schema.CommentBefore(sel.Position),
+				KotlinName:    kotlinName,
+				Redacted:      sel.Definition.Directives.ForName("redacted") != nil,
+				ForcedNonNull: forcedNonNull,
+				Selection:     sel,
+			})
+		case *ast.FragmentSpread:
+			if err := g.addFragment(s, class, parent, sel.Definition.TypeCondition,
+				sel.Definition.SelectionSet, depth); err != nil {
+				return fmt.Errorf("fragment %s: %w", sel.Name, err)
+			}
+		case *ast.InlineFragment:
+			if err := g.addFragment(s, class, parent, sel.TypeCondition, sel.SelectionSet, depth); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported selection of type %T", selection)
+		}
+	}
+	return nil
+}
+
+// mergeDuplicateField handles a selection whose alias is already a field of
+// the class, which is legal GraphQL and common via fragments. A composite
+// re-selection merges its sub-selections into the existing nested class; a
+// duplicate selecting a different schema field is a conflict.
+func (g *Generator) mergeDuplicateField(s *schema.Schema, class *classModel, existing *fieldModel,
+	sel *ast.Field, depth int) error {
+	if existing.Selection == nil || existing.Selection.Name != sel.Name ||
+		existing.Selection.Definition.Type.String() != sel.Definition.Type.String() {
+		return aliasConflictError(existing, sel)
+	}
+	if len(sel.SelectionSet) == 0 {
+		// A repeated scalar selection of the same field adds nothing.
+		return nil
+	}
+	name := upperFirst(sel.Alias)
+	if sel.Definition.Type.Elem != nil {
+		name += "Item"
+	}
+	nested := class.nested(className(name))
+	if nested == nil {
+		return fmt.Errorf("field %q is selected both with and without a selection set", sel.Alias)
+	}
+	return g.addSelections(s, nested, s.Types[sel.Definition.Type.Name()], sel.SelectionSet, depth+1)
+}
+
+// aliasConflictError describes both bindings of an alias used for two
+// incompatible selections: each side's field, type, and location, so the
+// conflict can be fixed without hunting through fragments.
+func aliasConflictError(existing *fieldModel, sel *ast.Field) error {
+	previous := fmt.Sprintf("a generated %s field", existing.Type)
+	if existing.Selection != nil {
+		previous = fmt.Sprintf("field %s: %s at %s", existing.Selection.Name,
+			existing.Selection.Definition.Type.String(), position(existing.Selection.Position))
+	}
+	return fmt.Errorf("alias %q is bound to %s, and to field %s: %s at %s",
+		sel.Alias, previous, sel.Name, sel.Definition.Type.String(), position(sel.Position))
+}
+
+// position renders a source location for an error message.
+func position(pos *ast.Position) string {
+	if pos == nil || pos.Src == nil {
+		return "(unknown location)"
+	}
+	return fmt.Sprintf("%s:%d", pos.Src.Name, pos.Line)
+}
+
+// addFragment merges a fragment's selections into a class.
+func (g *Generator) addFragment(s *schema.Schema, class *classModel, parent *ast.Definition,
+	condition string, selections ast.SelectionSet, depth int) error {
+	conditionDef := parent
+	if condition != "" {
+		conditionDef = s.Types[condition]
+		if conditionDef == nil {
+			return fmt.Errorf("unknown type %s in fragment type condition", condition)
+		}
+	}
+	if conditionDef == parent || broadens(conditionDef, parent) {
+		// The fragment's fields are common to every concrete type here, so
+		// they flatten straight into the class.
+		return g.addSelections(s, class, conditionDef, selections, depth)
+	}
+	if parent.Kind != ast.Interface && parent.Kind != ast.Union {
+		return fmt.Errorf("fragment on %s cannot apply to %s", condition, parent.Name)
+	}
+	// The fragment narrows an abstract type to one concrete type: generate a
+	// nullable per-type section.
+	sectionName := "As" + conditionDef.Name
+	if class.field(lowerFirst(sectionName)) != nil {
+		return nil
+	}
+	nested, err := g.selectionClass(s, sectionName, conditionDef, selections, depth+1)
+	if err != nil {
+		return err
+	}
+	class.Nested = append(class.Nested, nested)
+	class.Fields = append(class.Fields, &fieldModel{
+		Name: lowerFirst(sectionName),
+		Type: sectionName + "?",
+		Doc: fmt.Sprintf("The %s-specific fields, or null when this %s is not a %s.",
+			conditionDef.Name, parent.Name, conditionDef.Name),
+	})
+	return nil
+}
+
+// broadens reports whether condition is an interface parent implements or a
+// union parent is a member of, i.e. whether a fragment on condition selects
+// fields common to every possible parent value.
+func broadens(condition, parent *ast.Definition) bool {
+	switch condition.Kind {
+	case ast.Interface:
+		for _, name := range parent.Interfaces {
+			if name == condition.Name {
+				return true
+			}
+		}
+	case ast.Union:
+		for _, name := range condition.Types {
+			if name == parent.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// responseFieldType resolves a response field's type, synthesizing a nested
+// class when the field has a selection set of its own.
+func (g *Generator) responseFieldType(s *schema.Schema, field *ast.Field, t *ast.Type,
+	class *classModel, inList bool, depth int) (string, error) {
+	if t.Elem != nil {
+		elem, err := g.responseFieldType(s, field, t.Elem, class, true, depth)
+		if err != nil {
+			return "", err
+		}
+		name := "List<" + elem + ">"
+		if !t.NonNull {
+			name += "?"
+		}
+		return name, nil
+	}
+	var name string
+	if keyName, ok := g.typedKeyName(s, s.Types[t.NamedType]); ok && len(field.SelectionSet) > 0 {
+		// Key selections decode into the shared typed key class rather
+		// than a per-operation nested class.
+		name = keyName
+	} else if len(field.SelectionSet) > 0 {
+		name = upperFirst(field.Alias)
+		if inList {
+			name += "Item"
+		}
+		name = className(name)
+		nested, err := g.selectionClass(s, name, s.Types[t.NamedType], field.SelectionSet, depth+1)
+		if err != nil {
+			return "", fmt.Errorf("field %s: %w", field.Alias, err)
+		}
+		class.Nested = append(class.Nested, nested)
+	} else if def := s.Types[t.NamedType]; def != nil && def.Kind == ast.Enum {
+		name = def.Name
+		g.noteEnum(def)
+	} else {
+		name = g.scalarType(t.NamedType)
+	}
+	if !t.NonNull {
+		name += "?"
+	}
+	return name, nil
+}
+
+func trimNullability(kotlinType string) string {
+	if len(kotlinType) > 0 && kotlinType[len(kotlinType)-1] == '?' {
+		return kotlinType[:len(kotlinType)-1]
+	}
+	return kotlinType
+}
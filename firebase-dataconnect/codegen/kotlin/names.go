@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import "strings"
+
+// kotlinHardKeywords are the Kotlin keywords that cannot be used as
+// identifiers without backtick escaping.
+var kotlinHardKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "false": true, "for": true, "fun": true, "if": true,
+	"in": true, "interface": true, "is": true, "null": true, "object": true,
+	"package": true, "return": true, "super": true, "this": true,
+	"throw": true, "true": true, "try": true, "typealias": true,
+	"typeof": true, "val": true, "var": true, "when": true, "while": true,
+}
+
+// propertyName returns name as a Kotlin identifier, backtick-escaping hard
+// keywords.
+func propertyName(name string) string {
+	if kotlinHardKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// reservedClassNames are names that a generated nested class must not use:
+// they would shadow the generated wrapper classes or Kotlin built-in types
+// and produce uncompilable or ambiguous code.
+var reservedClassNames = map[string]bool{
+	"Variables": true, "Data": true, "CacheKey": true,
+	"Any": true, "Boolean": true, "Double": true, "Float": true,
+	"Int": true, "List": true, "Long": true, "Nothing": true,
+	"String": true, "Unit": true,
+}
+
+// className maps a schema type or field name to a generated class name,
+// suffixing names that would shadow a wrapper class or a Kotlin built-in.
+func className(name string) string {
+	name = upperFirst(name)
+	if reservedClassNames[name] {
+		return name + "_"
+	}
+	return name
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
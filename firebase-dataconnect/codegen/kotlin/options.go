@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operationOptions are per-operation overrides parsed from a structured
+// comment line preceding the operation, e.g.
+//
+//	# codegen: rxJava=false, unitTests=true
+//
+// They override the corresponding Generator flags for that operation only,
+// which lets a connector migrate to a new generated API style one operation
+// at a time.
+type operationOptions map[string]string
+
+// operationOptionBool records the recognized option names and whether each
+// takes a boolean value.
+var operationOptionBool = map[string]bool{
+	"authGuards":      true,
+	"composePreviews": true,
+	"emulatorTests":   true,
+	"grpcStubs":       true,
+	"liveData":        true,
+	"rxJava":          true,
+	"unitTests":       true,
+	"serialization":   false,
+}
+
+// parseOperationOptions extracts the "codegen:" lines from an operation's
+// doc comment. It returns the parsed options and the doc with those lines
+// removed, so option comments do not end up in generated KDoc.
+func parseOperationOptions(doc string) (operationOptions, string, error) {
+	var options operationOptions
+	var kept []string
+	for _, line := range strings.Split(doc, "\n") {
+		rest, found := strings.CutPrefix(strings.TrimSpace(line), "codegen:")
+		if !found {
+			kept = append(kept, line)
+			continue
+		}
+		for _, pair := range strings.Split(rest, ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if !ok || name == "" || value == "" {
+				return nil, "", fmt.Errorf("invalid codegen option %q: expected name=value", strings.TrimSpace(pair))
+			}
+			isBool, known := operationOptionBool[name]
+			if !known {
+				return nil, "", fmt.Errorf("unknown codegen option %q", name)
+			}
+			if isBool && value != "true" && value != "false" {
+				return nil, "", fmt.Errorf("codegen option %q must be true or false, not %q", name, value)
+			}
+			if name == "serialization" && value != "moshi" && value != "gson" {
+				return nil, "", fmt.Errorf("codegen option serialization must be moshi or gson, not %q", value)
+			}
+			if options == nil {
+				options = operationOptions{}
+			}
+			options[name] = value
+		}
+	}
+	return options, strings.TrimSpace(strings.Join(kept, "\n")), nil
+}
+
+// boolOption returns the operation's override for a boolean option, or
+// fallback when the operation does not set it.
+func (m *operationModel) boolOption(name string, fallback bool) bool {
+	if value, ok := m.Options[name]; ok {
+		return value == "true"
+	}
+	return fallback
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// renderConnectionPager writes the shared pager over the synthesized
+// connection query fields: callers adapt one page load -- typically a
+// <plural>Connection query execution -- and loadNext accumulates pages
+// until the cursor runs out.
+func renderConnectionPager(w *writer, kotlinPackage string) {
+	w.line("package %s", kotlinPackage)
+	w.line("")
+	w.line("/** One loaded page of a connection query. */")
+	w.line("public data class LoadedPage<T>(")
+	w.in()
+	w.line("val items: List<T>,")
+	w.line("val hasNextPage: Boolean,")
+	w.line("val endCursor: String?,")
+	w.out()
+	w.line(")")
+	w.line("")
+	w.line("/** Accumulates the pages of a connection query via [loadNext]. */")
+	w.line("public class ConnectionPager<T>(")
+	w.in()
+	w.line("private val load: suspend (after: String?) -> LoadedPage<T>,")
+	w.out()
+	w.line(") {")
+	w.in()
+	w.line("/** Every item loaded so far, in page order. */")
+	w.line("public val items: MutableList<T> = mutableListOf()")
+	w.line("")
+	w.line("public var hasNextPage: Boolean = true")
+	w.in()
+	w.line("private set")
+	w.out()
+	w.line("")
+	w.line("private var endCursor: String? = null")
+	w.line("")
+	w.line("/** Loads the next page and returns its items, empty once exhausted. */")
+	w.line("public suspend fun loadNext(): List<T> {")
+	w.in()
+	w.line("if (!hasNextPage) return emptyList()")
+	w.line("val page = load(endCursor)")
+	w.line("items += page.items")
+	w.line("hasNextPage = page.hasNextPage")
+	w.line("endCursor = page.endCursor")
+	w.line("return page.items")
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+)
+
+// renderPreviewProvider writes a Compose PreviewParameterProvider supplying
+// a sample Data instance for the operation, so previews of composables that
+// take the operation's result can render without a backend.
+func (g *Generator) renderPreviewProvider(w *writer, m *operationModel) {
+	w.line("package %s", m.Package)
+	w.line("")
+	dataType := m.ClassName + ".Data"
+	w.line("public class %sDataPreviewParameterProvider :", m.ClassName)
+	w.in()
+	w.line("androidx.compose.ui.tooling.preview.PreviewParameterProvider<%s> {", dataType)
+	w.line("override val values: Sequence<%s> = sequenceOf(", dataType)
+	w.in()
+	g.renderSampleInstance(w, dataType, m.Data)
+	w.line(",")
+	w.out()
+	w.line(")")
+	w.out()
+	w.line("}")
+}
+
+// renderSampleInstance emits a constructor call for class c with a
+// placeholder value for every property. qualifier is the fully qualified
+// name of c, and nested classes are qualified beneath it. Every placeholder
+// must match the property's declared type -- a String for an enum, UUID, or
+// ByteArray column would not compile.
+func (g *Generator) renderSampleInstance(w *writer, qualifier string, c *classModel) {
+	w.line("%s(", qualifier)
+	w.in()
+	for _, field := range c.Fields {
+		base := trimNullability(field.Type)
+		switch {
+		case base != field.Type:
+			w.line("%s = null,", field.property())
+		case base == "String":
+			w.line("%s = %q,", field.property(), "sample-"+field.Name)
+		default:
+			if _, isList := listElementType(base); isList {
+				w.line("%s = emptyList(),", field.property())
+				continue
+			}
+			if nested := c.nested(base); nested != nil {
+				w.line("%s =", field.property())
+				w.in()
+				g.renderSampleInstance(w, qualifier+"."+base, nested)
+				w.line(",")
+				w.out()
+				continue
+			}
+			w.line("%s = %s,", field.property(), g.sampleExpression(base, field.Name))
+		}
+	}
+	w.out()
+	w.line(")")
+}
+
+// sampleExpression returns a Kotlin expression of the given non-null type
+// for one placeholder property. Custom-mapped scalar types the generator
+// knows nothing about fall back to a String literal, which is also the type
+// custom scalars carry by default.
+func (g *Generator) sampleExpression(kotlinType, fieldName string) string {
+	if def := g.usedEnums[kotlinType]; def != nil && len(def.EnumValues) > 0 {
+		return kotlinType + "." + def.EnumValues[0].Name
+	}
+	switch kotlinType {
+	case "Int":
+		return "0"
+	case "Long":
+		return "0L"
+	case "Double":
+		return "0.0"
+	case "Float":
+		return "0f"
+	case "Boolean":
+		return "false"
+	case "ByteArray":
+		return "ByteArray(0)"
+	case "java.util.UUID":
+		return `java.util.UUID.fromString("00000000-0000-4000-8000-000000000000")`
+	case "LatLng":
+		return "LatLng(0.0, 0.0)"
+	case "kotlinx.datetime.LocalDate":
+		return "kotlinx.datetime.LocalDate(2026, 1, 1)"
+	case "kotlinx.datetime.Instant":
+		return "kotlinx.datetime.Instant.fromEpochMilliseconds(0)"
+	}
+	return fmt.Sprintf("%q", "sample-"+fieldName)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generateProguardRules writes the R8/ProGuard keep rules the generated
+// classes need in minified release builds. Without them R8 strips the
+// kotlinx.serialization companions and generated serializers -- nothing in
+// app code references them by name -- and deserialization crashes only at
+// runtime, only in release builds. The rules are scoped to the generated
+// packages so they do not widen what R8 keeps elsewhere.
+func (g *Generator) generateProguardRules(models []*operationModel) error {
+	if err := os.MkdirAll(g.DestDir, 0o777); err != nil {
+		return err
+	}
+	g.countFile()
+	return streamFile(filepath.Join(g.DestDir, g.Connector+"-proguard-rules.pro"), func(w *writer) {
+		w.line("# Generated by the Firebase Data Connect Kotlin code generator. Do not edit.")
+		if g.InputChecksum != "" {
+			w.line("# Input checksum: %s", g.InputChecksum)
+		}
+		w.line("")
+		w.line("# Keep the serialization machinery of the generated classes.")
+		w.line("-keepattributes *Annotation*, InnerClasses")
+		for _, pkg := range g.rulePackages(models) {
+			w.line("-keepclassmembers class %s.** {", pkg)
+			w.line("    *** Companion;")
+			w.line("}")
+			w.line("-keepclasseswithmembers class %s.** {", pkg)
+			w.line("    kotlinx.serialization.KSerializer serializer(...);")
+			w.line("}")
+			w.line("-if @kotlinx.serialization.Serializable class %s.**", pkg)
+			w.line("-keep,includedescriptorclasses class %s.<1>$$serializer {", pkg)
+			w.line("    *** INSTANCE;")
+			w.line("}")
+			if g.Serialization == "gson" {
+				w.line("")
+				w.line("# Gson reads fields reflectively; keep their names.")
+				w.line("-keepclassmembers class %s.** {", pkg)
+				w.line("    <fields>;")
+				w.line("}")
+			}
+		}
+	})
+}
+
+// rulePackages returns the package roots the keep rules must cover: the
+// connector's base package, whose `.**` pattern also matches the per-kind
+// shard subpackages, plus any @ktPackage override pointing outside it.
+func (g *Generator) rulePackages(models []*operationModel) []string {
+	packages := []string{g.Package}
+	seen := map[string]bool{g.Package: true}
+	for _, model := range models {
+		pkg := model.Package
+		if seen[pkg] || strings.HasPrefix(pkg, g.Package+".") {
+			continue
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
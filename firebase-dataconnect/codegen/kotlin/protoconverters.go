@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// protoKotlinTypes maps the proto3 field types the generator emits to the
+// Kotlin types their Java accessors carry.
+var protoKotlinTypes = map[string]string{
+	"string": "String",
+	"int32":  "Int",
+	"double": "Double",
+	"bool":   "Boolean",
+	"int64":  "Long",
+}
+
+// protoField describes one field of a generated proto message.
+type protoField struct {
+	Name       string
+	KotlinType string
+	Repeated   bool
+	Optional   bool
+}
+
+// protoConverter is one generated conversion pair: a toProto extension on a
+// response class building the table's proto message, and a from-proto
+// extension rebuilding the class.
+type protoConverter struct {
+	Receiver string
+	Suffix   string
+	Message  string
+	Source   *classModel
+	Fields   map[string]protoField
+}
+
+// generateProtoConverters writes <Connector>ProtoConverters.kt: for every
+// response class whose selections map one-to-one onto the proto message
+// generated for its table, extensions converting in both directions. Fetched
+// rows can then be persisted in Proto DataStore and restored without
+// hand-written field copying.
+func (g *Generator) generateProtoConverters(s *schema.Schema, models []*operationModel) error {
+	protoFields := map[string]map[string]protoField{}
+	for _, table := range s.Tables {
+		protoFields[table.Name] = protoFieldsOf(s, table)
+	}
+	var converters []protoConverter
+	for _, model := range models {
+		if model.Data == nil {
+			continue
+		}
+		g.collectProtoConverters(
+			g.qualifiedOperation(model)+"."+model.Data.Name,
+			model.ClassName+model.Data.Name,
+			model.Data, protoFields, &converters)
+	}
+	if len(converters) == 0 {
+		return nil
+	}
+	return g.writeFile(upperCamel(g.Connector)+"ProtoConverters.kt", func(w *writer) {
+		w.line("package %s", g.Package)
+		for _, converter := range converters {
+			renderProtoConverter(w, converter)
+		}
+	})
+}
+
+// collectProtoConverters walks a response class tree and records every class
+// whose selections cover a proto message, in declaration order.
+func (g *Generator) collectProtoConverters(qualified, suffix string, c *classModel, protoFields map[string]map[string]protoField, converters *[]protoConverter) {
+	if table := selectionTable(c); table != "" {
+		if fields, ok := protoFields[table]; ok && coversProtoFields(c, fields) {
+			*converters = append(*converters, protoConverter{
+				Receiver: qualified,
+				Suffix:   suffix,
+				Message:  g.Package + ".proto." + table,
+				Source:   c,
+				Fields:   fields,
+			})
+		}
+	}
+	for _, nested := range c.Nested {
+		g.collectProtoConverters(qualified+"."+nested.Name, suffix+nested.Name, nested, protoFields, converters)
+	}
+}
+
+// coversProtoFields reports whether every field of a response class maps
+// losslessly onto a proto message field: the selection names a message field
+// and the Kotlin type matches the field's cardinality exactly, so neither
+// direction of the conversion can drop or invent a value.
+func coversProtoFields(c *classModel, fields map[string]protoField) bool {
+	for _, field := range c.Fields {
+		if field.Selection == nil {
+			return false
+		}
+		pf, ok := fields[field.Selection.Name]
+		if !ok {
+			return false
+		}
+		want := pf.KotlinType
+		if pf.Repeated {
+			want = "List<" + want + ">"
+		}
+		if pf.Optional {
+			want += "?"
+		}
+		if field.Type != want {
+			return false
+		}
+	}
+	return len(c.Fields) > 0
+}
+
+// protoFieldsOf indexes a table's proto message fields by schema field name,
+// mirroring the shapes renderProtoMessage emits.
+func protoFieldsOf(s *schema.Schema, table *ast.Definition) map[string]protoField {
+	fields := map[string]protoField{}
+	for _, field := range table.Fields {
+		if underlying := s.Types[field.Type.Name()]; underlying != nil && underlying.Kind == ast.Object {
+			continue
+		}
+		fieldType := field.Type
+		repeated := fieldType.Elem != nil
+		if repeated {
+			fieldType = fieldType.Elem
+		}
+		protoType, ok := protoScalarTypes[fieldType.NamedType]
+		if !ok {
+			protoType = "string"
+		}
+		fields[field.Name] = protoField{
+			Name:       field.Name,
+			KotlinType: protoKotlinTypes[protoType],
+			Repeated:   repeated,
+			Optional:   !repeated && !field.Type.NonNull,
+		}
+	}
+	return fields
+}
+
+func renderProtoConverter(w *writer, converter protoConverter) {
+	w.line("")
+	w.line("/** Copies the fields selected into [%s] into a [%s]. */", converter.Receiver, converter.Message)
+	w.line("public fun %s.toProto(): %s {", converter.Receiver, converter.Message)
+	w.in()
+	w.line("val builder = %s.newBuilder()", converter.Message)
+	for _, field := range converter.Source.Fields {
+		pf := converter.Fields[field.Selection.Name]
+		accessor := protoAccessor(pf.Name)
+		switch {
+		case pf.Repeated:
+			w.line("builder.addAll%s(%s)", accessor, field.property())
+		case pf.Optional:
+			w.line("%s?.let { builder.set%s(it) }", field.property(), accessor)
+		default:
+			w.line("builder.set%s(%s)", accessor, field.property())
+		}
+	}
+	w.line("return builder.build()")
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("/** Rebuilds a [%s] from a stored [%s]. */", converter.Receiver, converter.Message)
+	w.line("public fun %s.to%s(): %s = %s(",
+		converter.Message, converter.Suffix, converter.Receiver, converter.Receiver)
+	w.in()
+	for _, field := range converter.Source.Fields {
+		pf := converter.Fields[field.Selection.Name]
+		accessor := protoAccessor(pf.Name)
+		switch {
+		case pf.Repeated:
+			w.line("%s = get%sList(),", field.property(), accessor)
+		case pf.Optional:
+			w.line("%s = if (has%s()) get%s() else null,", field.property(), accessor, accessor)
+		default:
+			w.line("%s = get%s(),", field.property(), accessor)
+		}
+	}
+	w.out()
+	w.line(")")
+}
+
+// protoAccessor returns the accessor stem protoc derives from a proto field
+// name, camelizing underscore-separated segments.
+func protoAccessor(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		parts[i] = upperFirst(part)
+	}
+	return strings.Join(parts, "")
+}
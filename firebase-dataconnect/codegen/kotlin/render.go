@@ -0,0 +1,428 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// writer streams generated source to its output, tracking indentation.
+type writer struct {
+	dest   *normalizer
+	indent int
+	err    error
+}
+
+// fail records the first error hit while rendering; streamFile surfaces it
+// once the render callback returns.
+func (w *writer) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *writer) line(format string, args ...any) {
+	if format == "" {
+		w.dest.WriteByte('\n')
+		return
+	}
+	w.dest.WriteString(strings.Repeat("  ", w.indent))
+	fmt.Fprintf(w.dest, format, args...)
+	w.dest.WriteByte('\n')
+}
+
+// streamFile renders a file through a buffered temp file in the destination
+// directory and renames it into place once rendering succeeds. Streaming
+// keeps memory bounded by the buffer rather than the output size, which
+// matters for connectors whose aggregate files run to megabytes, and the
+// rename means readers never observe a half-written file.
+func streamFile(path string, render func(w *writer)) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	w := &writer{dest: &normalizer{out: bufio.NewWriter(f)}}
+	render(w)
+	if w.err == nil {
+		w.fail(w.dest.close())
+	}
+	if w.err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return w.err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0o666); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (w *writer) in()  { w.indent++ }
+func (w *writer) out() { w.indent-- }
+
+// normalizer rewrites the rendered stream line by line so template or hook
+// edits cannot introduce whitespace-only diffs in committed generated code:
+// trailing whitespace is trimmed, runs of more than two blank lines are
+// collapsed to two, leading and trailing blank lines are dropped, and the
+// file always ends with a newline.
+type normalizer struct {
+	out *bufio.Writer
+
+	// line is the current, not yet terminated line.
+	line []byte
+
+	// blanks counts blank lines seen but not yet written.
+	blanks int
+
+	// wrote reports whether a non-blank line has been written.
+	wrote bool
+}
+
+func (n *normalizer) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' {
+			n.endLine()
+		} else {
+			n.line = append(n.line, b)
+		}
+	}
+	return len(p), nil
+}
+
+func (n *normalizer) WriteString(s string) (int, error) {
+	return n.Write([]byte(s))
+}
+
+func (n *normalizer) WriteByte(b byte) error {
+	_, err := n.Write([]byte{b})
+	return err
+}
+
+func (n *normalizer) endLine() {
+	trimmed := bytes.TrimRight(n.line, " \t")
+	n.line = n.line[:0]
+	if len(trimmed) == 0 {
+		n.blanks++
+		return
+	}
+	if n.wrote {
+		for i := 0; i < min(n.blanks, 2); i++ {
+			n.out.WriteByte('\n')
+		}
+	}
+	n.blanks = 0
+	n.wrote = true
+	n.out.Write(trimmed)
+	n.out.WriteByte('\n')
+}
+
+// close flushes a pending unterminated line -- gaining the final newline a
+// footer hook may have left off -- and discards trailing blank lines.
+func (n *normalizer) close() error {
+	if len(n.line) > 0 {
+		n.endLine()
+	}
+	return n.out.Flush()
+}
+
+func renderOperation(w *writer, m *operationModel) {
+	w.line("package %s", m.Package)
+	w.line("")
+	renderOperationObject(w, m)
+}
+
+// renderOperationObject writes an operation's object without the package
+// declaration, so single-file mode can concatenate several operations under
+// one.
+func renderOperationObject(w *writer, m *operationModel) {
+	renderKDoc(w, m.Doc)
+	w.line("public object %s {", m.ClassName)
+	w.in()
+	w.line("public const val operationName: String = %q", m.Name)
+	renderAuthMetadata(w, m)
+	w.line("")
+	if m.ResourcePath != "" {
+		w.line("public val operationSource: String by lazy {")
+		w.in()
+		w.line("checkNotNull(%s::class.java.classLoader.getResourceAsStream(%q)) {",
+			m.ClassName, m.ResourcePath)
+		w.in()
+		w.line(`"missing packaged resource %s"`, m.ResourcePath)
+		w.out()
+		w.line("}.bufferedReader().use { it.readText() }")
+		w.out()
+		w.line("}")
+	} else {
+		w.line("public val operationSource: String =")
+		w.in()
+		renderRawString(w, m.Source)
+		w.out()
+	}
+	if m.Variables != nil {
+		w.line("")
+		renderClass(w, m.Variables, m.Serialization)
+	}
+	if m.Diff != nil {
+		w.line("")
+		renderDiffHelper(w, m.Diff)
+	}
+	w.line("")
+	renderClass(w, m.Data, m.Serialization)
+	if m.Kind == ast.Query {
+		w.line("")
+		renderCacheKey(w, m)
+	}
+	w.out()
+	w.line("}")
+}
+
+// renderCacheKey emits a per-query key type pairing the operation with its
+// variables. Because each query gets its own CacheKey type, a cache keyed
+// by it cannot be handed another query's key.
+func renderCacheKey(w *writer, m *operationModel) {
+	if m.Variables == nil {
+		w.line("/** The cache key of this query's result. */")
+		w.line("public object CacheKey")
+		return
+	}
+	w.line("/** The cache key of this query's result for one set of variables. */")
+	w.line("public data class CacheKey(val variables: Variables)")
+	w.line("")
+	w.line("public fun cacheKey(variables: Variables): CacheKey = CacheKey(variables)")
+}
+
+func renderClass(w *writer, c *classModel, serialization string) {
+	renderKDoc(w, c.Doc)
+	// kotlinx.serialization only generates a serializer for classes carrying
+	// the @Serializable annotation; without it every decode and encode of the
+	// class fails at runtime.
+	switch serialization {
+	case "":
+		w.line("@kotlinx.serialization.Serializable")
+	case "moshi":
+		w.line("@com.squareup.moshi.JsonClass(generateAdapter = true)")
+	}
+	w.line("public data class %s(", c.Name)
+	w.in()
+	for _, field := range c.Fields {
+		renderKDoc(w, field.Doc)
+		renderFieldAnnotations(w, field, serialization)
+		suffix := ""
+		if field.HasDefault {
+			suffix = " = null"
+		}
+		w.line("val %s: %s%s,", field.property(), field.Type, suffix)
+	}
+	w.out()
+	if len(c.Nested) == 0 && len(c.Constructors) == 0 && !c.anyRedacted() && !c.anyByteArray() {
+		w.line(")")
+		return
+	}
+	w.line(") {")
+	w.in()
+	if len(c.Constructors) > 0 && c.Constructors[0].Builder {
+		w.line("")
+		renderBuilders(w, c)
+	} else {
+		for _, constructor := range c.Constructors {
+			w.line("")
+			renderConstructor(w, constructor)
+		}
+	}
+	if c.anyRedacted() {
+		w.line("")
+		renderRedactedToString(w, c)
+	}
+	if c.anyByteArray() {
+		w.line("")
+		renderByteArrayEquality(w, c)
+	}
+	for _, nested := range c.Nested {
+		w.line("")
+		renderClass(w, nested, serialization)
+	}
+	w.out()
+	w.line("}")
+}
+
+// renderFieldAnnotations binds a property back to its GraphQL name for the
+// selected serialization library. Only properties renamed by @ktName or
+// whose Kotlin name had to be escaped need an explicit binding; escaping
+// alone leaves the serialized name intact, so kotlinx needs no annotation
+// for it. ByteArray properties additionally name the base64 serializer the
+// Bytes scalar travels through.
+func renderFieldAnnotations(w *writer, field *fieldModel, serialization string) {
+	if serialization == "" && isByteArray(field.Type) {
+		w.line("@kotlinx.serialization.Serializable(with = ByteArrayBase64Serializer::class)")
+	}
+	renamed := field.KotlinName != ""
+	if !renamed && propertyName(field.Name) == field.Name {
+		return
+	}
+	switch serialization {
+	case "":
+		if renamed {
+			w.line("@kotlinx.serialization.SerialName(%q)", field.Name)
+		}
+	case "moshi":
+		w.line("@com.squareup.moshi.Json(name = %q)", field.Name)
+	case "gson":
+		w.line("@com.google.gson.annotations.SerializedName(%q)", field.Name)
+	}
+}
+
+// renderRedactedToString overrides toString() so that fields declared
+// @redacted in the schema do not leak their values into logs.
+func renderRedactedToString(w *writer, c *classModel) {
+	var parts []string
+	for _, field := range c.Fields {
+		if field.Redacted {
+			parts = append(parts, field.Name+"=<redacted>")
+		} else {
+			parts = append(parts, fmt.Sprintf("%s=${%s}", field.Name, field.property()))
+		}
+	}
+	w.line("override fun toString(): String =")
+	w.in()
+	w.line(`"%s(%s)"`, c.Name, strings.Join(parts, ", "))
+	w.out()
+}
+
+// renderConstructor emits a secondary constructor that flattens the fields
+// of a single input-object property into direct parameters. Fields the
+// server populates from an expression are not parameters.
+func renderConstructor(w *writer, c *constructorModel) {
+	if c.Vararg {
+		w.line("public constructor(vararg %s: %s) : this(%s = %s.toList())",
+			c.Field.property(), c.Class.Name,
+			c.Field.property(), c.Field.property())
+		return
+	}
+	w.line("public constructor(")
+	w.in()
+	renderConstructorParams(w, c)
+	w.out()
+	w.line(") : this(")
+	w.in()
+	w.line("%s = %s(", c.Field.property(), c.Class.Name)
+	w.in()
+	renderConstructorArgs(w, c)
+	w.out()
+	w.line(")")
+	w.out()
+	w.line(")")
+}
+
+// renderBuilders emits the named companion builder functions that replace
+// flattening constructors under Builders mode: one per input-object
+// variable, named after the variable and returning the input object.
+func renderBuilders(w *writer, c *classModel) {
+	w.line("public companion object {")
+	w.in()
+	for i, constructor := range c.Constructors {
+		if i > 0 {
+			w.line("")
+		}
+		w.line("/** Builds the [%s] passed as `%s`. */", constructor.Class.Name, constructor.Field.Name)
+		w.line("public fun %s(", constructor.Field.property())
+		w.in()
+		renderConstructorParams(w, constructor)
+		w.out()
+		w.line("): %s = %s(", constructor.Class.Name, constructor.Class.Name)
+		w.in()
+		renderConstructorArgs(w, constructor)
+		w.out()
+		w.line(")")
+	}
+	w.out()
+	w.line("}")
+}
+
+// renderConstructorParams lists the constructor's parameters, recursing
+// into the nested levels flattened in place.
+func renderConstructorParams(w *writer, c *constructorModel) {
+	for _, field := range c.Class.Fields {
+		if field.ServerExpr != "" {
+			continue
+		}
+		if sub := c.Nested[field.Name]; sub != nil {
+			renderConstructorParams(w, sub)
+			continue
+		}
+		suffix := ""
+		if field.HasDefault {
+			suffix = " = null"
+		}
+		w.line("%s: %s%s,", c.paramName(field), field.Type, suffix)
+	}
+}
+
+// renderConstructorArgs rebuilds the input objects, nested levels included,
+// from the flattened parameters.
+func renderConstructorArgs(w *writer, c *constructorModel) {
+	for _, field := range c.Class.Fields {
+		if field.ServerExpr != "" {
+			continue
+		}
+		if sub := c.Nested[field.Name]; sub != nil {
+			w.line("%s = %s(", field.property(), sub.Class.Name)
+			w.in()
+			renderConstructorArgs(w, sub)
+			w.out()
+			w.line("),")
+			continue
+		}
+		w.line("%s = %s,", field.property(), c.paramName(field))
+	}
+}
+
+func renderKDoc(w *writer, doc string) {
+	if doc == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(doc), "\n")
+	if len(lines) == 1 {
+		w.line("/** %s */", lines[0])
+		return
+	}
+	w.line("/**")
+	for _, line := range lines {
+		w.line(" * %s", strings.TrimRight(line, " \t"))
+	}
+	w.line(" */")
+}
+
+// renderRawString emits s as a Kotlin triple-quoted raw string, escaping '$'
+// so that GraphQL variable references are not treated as templates.
+func renderRawString(w *writer, s string) {
+	escaped := strings.ReplaceAll(s, "$", "${'$'}")
+	w.line(`"""`)
+	for _, line := range strings.Split(escaped, "\n") {
+		w.line("%s", strings.TrimRight(line, " \t"))
+	}
+	w.line(`""".trimIndent()`)
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// renderResultWrapper writes the shared OperationResult sealed class and the
+// runCatchingOperation helper, one copy per generated package.
+func renderResultWrapper(w *writer, kotlinPackage string) {
+	w.line("package %s", kotlinPackage)
+	w.line("")
+	w.line("/** The outcome of executing a connector operation. */")
+	w.line("public sealed class OperationResult<out T> {")
+	w.in()
+	w.line("public data class Success<out T>(val data: T) : OperationResult<T>()")
+	w.line("")
+	w.line("public data class Failure(val exception: Throwable) : OperationResult<Nothing>()")
+	w.line("")
+	w.line("/** The operation's data, or null when it failed. */")
+	w.line("public fun dataOrNull(): T? = (this as? Success)?.data")
+	w.line("")
+	w.line("/** The operation's data, rethrowing the failure's exception. */")
+	w.line("public fun dataOrThrow(): T = when (this) {")
+	w.in()
+	w.line("is Success -> data")
+	w.line("is Failure -> throw exception")
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("/** Runs [execute], capturing its result or failure as an [OperationResult]. */")
+	w.line("public suspend fun <T> runCatchingOperation(")
+	w.in()
+	w.line("execute: suspend () -> T,")
+	w.out()
+	w.line("): OperationResult<T> =")
+	w.in()
+	w.line("try {")
+	w.in()
+	w.line("OperationResult.Success(execute())")
+	w.out()
+	w.line("} catch (e: Throwable) {")
+	w.in()
+	w.line("OperationResult.Failure(e)")
+	w.out()
+	w.line("}")
+	w.out()
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// generateRoomEntities writes one Room @Entity class per @table type, for
+// apps that mirror Data Connect data into a local Room database.
+func (g *Generator) generateRoomEntities(s *schema.Schema) error {
+	for _, table := range s.Tables {
+		keyFields, err := s.KeyFields(table)
+		if err != nil {
+			return err
+		}
+		table := table
+		if err := g.writeFile(table.Name+"Entity.kt", func(w *writer) {
+			renderRoomEntity(w, g.Package, s, table, keyFields)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderRoomEntity(w *writer, kotlinPackage string, s *schema.Schema,
+	table *ast.Definition, keyFields []*ast.FieldDefinition) {
+	w.line("package %s", kotlinPackage)
+	w.line("")
+	if table.Description != "" {
+		renderKDoc(w, table.Description)
+	}
+	if len(keyFields) > 1 {
+		var names []string
+		for _, field := range keyFields {
+			names = append(names, `"`+columnName(field)+`"`)
+		}
+		w.line("@androidx.room.Entity(tableName = %q, primaryKeys = [%s])",
+			roomTableName(table), strings.Join(names, ", "))
+	} else {
+		w.line("@androidx.room.Entity(tableName = %q)", roomTableName(table))
+	}
+	w.line("public data class %sEntity(", table.Name)
+	w.in()
+	for _, field := range table.Fields {
+		if underlying := s.Types[field.Type.Name()]; underlying != nil && underlying.Kind == ast.Object {
+			// Relations are not mirrored into the entity.
+			continue
+		}
+		if field.Type.Elem != nil {
+			// Room has no built-in mapping for list columns.
+			continue
+		}
+		var annotations []string
+		if len(keyFields) == 1 && field.Name == keyFields[0].Name {
+			annotations = append(annotations, "@androidx.room.PrimaryKey")
+		}
+		if column := columnName(field); column != field.Name {
+			annotations = append(annotations, "@androidx.room.ColumnInfo(name = \""+column+"\")")
+		}
+		kotlinType := scalarType(field.Type.NamedType)
+		if !field.Type.NonNull {
+			kotlinType += "?"
+		}
+		prefix := ""
+		if len(annotations) > 0 {
+			prefix = strings.Join(annotations, " ") + " "
+		}
+		property := ktNameOf(field.Directives)
+		if property == "" {
+			property = propertyName(field.Name)
+		}
+		w.line("%sval %s: %s,", prefix, property, kotlinType)
+	}
+	w.out()
+	w.line(")")
+}
+
+// roomTableName is the @table(name:) argument, defaulting to the lowercased
+// type name.
+func roomTableName(table *ast.Definition) string {
+	if arg := table.Directives.ForName("table").Arguments.ForName("name"); arg != nil {
+		return arg.Value.Raw
+	}
+	return strings.ToLower(table.Name)
+}
+
+// columnName is the @col(name:) argument, defaulting to the field name.
+func columnName(field *ast.FieldDefinition) string {
+	if directive := field.Directives.ForName("col"); directive != nil {
+		if arg := directive.Arguments.ForName("name"); arg != nil {
+			return arg.Value.Raw
+		}
+	}
+	return field.Name
+}
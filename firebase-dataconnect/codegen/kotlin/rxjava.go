@@ -0,0 +1,42 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// renderRxJavaAdapter writes asSingle and asFlowable extensions adapting a
+// suspending executor for the operation into RxJava 3 sources.
+func renderRxJavaAdapter(w *writer, m *operationModel) {
+	dataType := m.ClassName + ".Data"
+	w.line("package %s", m.Package)
+	w.line("")
+	w.line("/** Runs [execute] on subscription and emits its result. */")
+	w.line("public fun %s.asSingle(", m.ClassName)
+	w.in()
+	w.line("execute: suspend () -> %s,", dataType)
+	w.out()
+	w.line("): io.reactivex.rxjava3.core.Single<%s> =", dataType)
+	w.in()
+	w.line("kotlinx.coroutines.rx3.rxSingle { execute() }")
+	w.out()
+	w.line("")
+	w.line("/** Runs [execute] on subscription, emits its result, and completes. */")
+	w.line("public fun %s.asFlowable(", m.ClassName)
+	w.in()
+	w.line("execute: suspend () -> %s,", dataType)
+	w.out()
+	w.line("): io.reactivex.rxjava3.core.Flowable<%s> =", dataType)
+	w.in()
+	w.line("kotlinx.coroutines.rx3.rxFlowable { send(execute()) }")
+	w.out()
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// generateSeedScripts writes one insert mutation per @table type plus a
+// manifest listing them in execution order, so local development and demos
+// can seed the emulator with a sample row per table instead of hand-writing
+// fixtures.
+func (g *Generator) generateSeedScripts(s *schema.Schema) error {
+	seedDir := filepath.Join(g.DestDir, "seed")
+	if err := os.MkdirAll(seedDir, 0o777); err != nil {
+		return err
+	}
+	tables := append([]*ast.Definition(nil), s.Tables...)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	type seedScript struct {
+		Table string `json:"table"`
+		File  string `json:"file"`
+	}
+	manifest := struct {
+		Version int          `json:"version"`
+		Scripts []seedScript `json:"scripts"`
+	}{Version: 1}
+	for _, table := range tables {
+		insert := insertField(s, table)
+		if insert == nil {
+			continue
+		}
+		name := "seed-" + strings.ToLower(table.Name) + ".gql"
+		g.countFile()
+		if err := streamFile(filepath.Join(seedDir, name), func(w *writer) {
+			w.line("# Generated by the Firebase Data Connect Kotlin code generator. Do not edit.")
+			w.line("mutation Seed%s {", table.Name)
+			w.in()
+			w.line("%s(data: {%s})", insert.Name, seedData(s, table))
+			w.out()
+			w.line("}")
+		}); err != nil {
+			return err
+		}
+		manifest.Scripts = append(manifest.Scripts, seedScript{Table: table.Name, File: name})
+	}
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	g.countFile()
+	return os.WriteFile(filepath.Join(seedDir, "manifest.json"), append(contents, '\n'), 0o666)
+}
+
+// insertField returns the synthesized insert mutation for a table.
+func insertField(s *schema.Schema, table *ast.Definition) *ast.FieldDefinition {
+	for _, field := range s.Mutation.Fields {
+		if !strings.HasSuffix(field.Name, "_insert") {
+			continue
+		}
+		if arg := field.Arguments.ForName("data"); arg != nil && arg.Type.Name() == table.Name+"_Data" {
+			return field
+		}
+	}
+	return nil
+}
+
+// seedData builds the data literal of a table's seed insert: a sample value
+// for every required field, leaving defaulted and nullable fields to the
+// server.
+func seedData(s *schema.Schema, table *ast.Definition) string {
+	data := s.Types[table.Name+"_Data"]
+	var fields []string
+	for _, field := range data.Fields {
+		if !field.Type.NonNull {
+			continue
+		}
+		fields = append(fields, field.Name+": "+seedValue(s, field.Type))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// seedValue returns a sample GraphQL literal for one field type.
+func seedValue(s *schema.Schema, t *ast.Type) string {
+	if t.Elem != nil {
+		return "[]"
+	}
+	if def := s.Types[t.NamedType]; def != nil && def.Kind == ast.Enum {
+		return def.EnumValues[0].Name
+	}
+	switch t.NamedType {
+	case "Int", "Int64":
+		return "1"
+	case "Float":
+		return "1.5"
+	case "Boolean":
+		return "true"
+	case "UUID":
+		return `"00000000-0000-0000-0000-000000000000"`
+	case "Date":
+		return `"2026-01-01"`
+	case "Timestamp":
+		return `"2026-01-01T00:00:00Z"`
+	default:
+		return fmt.Sprintf("%q", "sample")
+	}
+}
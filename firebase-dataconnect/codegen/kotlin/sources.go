@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sourceDescriptorName is the machine-readable descriptor of the source
+// roots generated into a dest dir, consumed by the Gradle plugin to wire
+// srcDirs and task dependencies without hardcoding the layout.
+const sourceDescriptorName = ".dataconnect-sources.json"
+
+type sourceDescriptor struct {
+	Version    int              `json:"version"`
+	Connectors []connectorRoots `json:"connectors"`
+}
+
+type connectorRoots struct {
+	Connector string `json:"connector"`
+	Package   string `json:"package"`
+
+	// SourceSets maps a Gradle source set to its root kinds ("kotlin",
+	// "resources") and their directories, relative to the dest dir.
+	SourceSets map[string]map[string][]string `json:"sourceSets"`
+}
+
+// generateSourceDescriptor records this connector's generated source roots
+// in the dest dir's descriptor, preserving the entries of other connectors
+// generating into the same dir.
+func (g *Generator) generateSourceDescriptor() error {
+	path := filepath.Join(g.DestDir, sourceDescriptorName)
+	descriptor := sourceDescriptor{Version: 1}
+	if contents, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(contents, &descriptor); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	roots := connectorRoots{
+		Connector: g.Connector,
+		Package:   g.Package,
+		SourceSets: map[string]map[string][]string{
+			"main": {"kotlin": []string{strings.ReplaceAll(g.Package, ".", "/")}},
+		},
+	}
+	if g.ResourceOperations {
+		roots.SourceSets["main"]["resources"] = []string{"resources"}
+	}
+	if g.EmulatorTests {
+		roots.SourceSets["androidTest"] = map[string][]string{"kotlin": {"androidTest"}}
+	}
+	if g.UnitTests {
+		roots.SourceSets["test"] = map[string][]string{"kotlin": {"test"}}
+	}
+	kept := descriptor.Connectors[:0]
+	for _, existing := range descriptor.Connectors {
+		if existing.Connector != g.Connector {
+			kept = append(kept, existing)
+		}
+	}
+	descriptor.Connectors = append(kept, roots)
+	sort.Slice(descriptor.Connectors, func(i, j int) bool {
+		return descriptor.Connectors[i].Connector < descriptor.Connectors[j].Connector
+	})
+	contents, err := json.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(g.DestDir, 0o777); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o666)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"text/template"
+)
+
+// templateDataVersion is the version of the data contract handed to
+// user-supplied override templates. It is bumped whenever a field of
+// templateData is removed or changes meaning; additions are backwards
+// compatible and do not bump it.
+const templateDataVersion = 1
+
+// templateData is what an override template is rendered with. Fields are
+// only ever added within a data-contract version.
+type templateData struct {
+	// Package and Connector identify what is being generated.
+	Package   string
+	Connector string
+
+	// File is the name of the file the template is rendered into.
+	File string
+
+	// Checksum is the input checksum stamped into generated headers, empty
+	// when checksums are disabled.
+	Checksum string
+}
+
+// templateVersionPragma is the declaration every override template must
+// carry, e.g. {{/* dataconnect:template-version=1 */}}.
+var templateVersionPragma = regexp.MustCompile(`dataconnect:template-version=(\d+)`)
+
+// checkTemplateVersion verifies that an override template declares the
+// data-contract version it was written against and that it matches this
+// generator's. A template written against another contract would render
+// garbage -- missing fields render as empty strings -- so the mismatch is
+// surfaced as an error instead.
+func checkTemplateVersion(name, contents string) error {
+	match := templateVersionPragma.FindStringSubmatch(contents)
+	if match == nil {
+		return fmt.Errorf(
+			"%s: missing data-contract declaration; add {{/* dataconnect:template-version=%d */}} to the template",
+			name, templateDataVersion)
+	}
+	if match[1] != strconv.Itoa(templateDataVersion) {
+		return fmt.Errorf(
+			"%s targets template data version %s, but this generator provides version %d; update the template to the current contract",
+			name, match[1], templateDataVersion)
+	}
+	return nil
+}
+
+// operationTemplateData is what an operation override template is rendered
+// with. It extends templateData's contract with the operation being
+// generated; like templateData, fields are only ever added within a
+// data-contract version.
+type operationTemplateData struct {
+	templateData
+
+	// Name is the GraphQL operation name and ClassName the Kotlin object
+	// the built-in renderer would declare for it.
+	Name      string
+	ClassName string
+
+	// Kind is "query" or "mutation".
+	Kind string
+
+	// Source is the operation's GraphQL text and Doc the comment block
+	// preceding it, if any.
+	Source string
+	Doc    string
+}
+
+// operationTemplateName is the override template replacing the built-in
+// rendering of each operation's Kotlin file.
+const operationTemplateName = "operation.gotmpl"
+
+// loadTemplateOverrides parses the recognized override templates present in
+// dir; a missing file simply falls back to the built-in renderer. The hook
+// names are ignored so TemplateDir and TemplateHookDir may point at the
+// same directory, but any other .gotmpl file is an error -- a typo must not
+// silently fall back.
+func loadTemplateOverrides(dir string) (map[string]*template.Template, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	overrides := map[string]*template.Template{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".gotmpl" || name == "header.gotmpl" || name == "footer.gotmpl" {
+			continue
+		}
+		if name != operationTemplateName {
+			return nil, fmt.Errorf("%s: unrecognized override template %s; only %s is supported",
+				dir, name, operationTemplateName)
+		}
+		path := filepath.Join(dir, name)
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTemplateVersion(path, string(contents)); err != nil {
+			return nil, err
+		}
+		parsed, err := template.New(name).Option("missingkey=error").Parse(string(contents))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		overrides[name] = parsed
+	}
+	return overrides, nil
+}
+
+// templateHooks are the optional user templates rendered around every
+// generated file, for org-specific annotations, suppressions, or banners.
+type templateHooks struct {
+	header *template.Template
+	footer *template.Template
+}
+
+// loadTemplateHooks parses header.gotmpl and footer.gotmpl from dir, either
+// of which may be absent. Each present template must declare the data
+// contract it targets.
+func loadTemplateHooks(dir string) (*templateHooks, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	hooks := &templateHooks{}
+	for name, slot := range map[string]**template.Template{
+		"header.gotmpl": &hooks.header,
+		"footer.gotmpl": &hooks.footer,
+	} {
+		path := filepath.Join(dir, name)
+		contents, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTemplateVersion(path, string(contents)); err != nil {
+			return nil, err
+		}
+		parsed, err := template.New(name).Option("missingkey=error").Parse(string(contents))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		*slot = parsed
+	}
+	return hooks, nil
+}
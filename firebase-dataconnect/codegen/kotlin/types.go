@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+// builtinScalarTypes maps the built-in GraphQL scalars to Kotlin types.
+var builtinScalarTypes = map[string]string{
+	"String":  "String",
+	"ID":      "String",
+	"Int":     "Int",
+	"Float":   "Double",
+	"Boolean": "Boolean",
+}
+
+// scalarType returns the Kotlin type a GraphQL scalar maps to. Custom scalars
+// are carried in their serialized JSON form, except LatLng, which maps to
+// the generated LatLng class.
+func scalarType(name string) string {
+	if kotlinType, ok := builtinScalarTypes[name]; ok {
+		return kotlinType
+	}
+	if name == "LatLng" {
+		return "LatLng"
+	}
+	if name == "Bytes" {
+		return "ByteArray"
+	}
+	return "String"
+}
+
+// scalarType is the Generator-aware mapping. A ScalarMappings entry wins;
+// otherwise, with KotlinxDatetime enabled, the Date and Timestamp scalars
+// map to kotlinx-datetime types rather than their serialized string form.
+func (g *Generator) scalarType(name string) string {
+	if kotlinType, ok := g.ScalarMappings[name]; ok {
+		return kotlinType
+	}
+	if g.KotlinxDatetime {
+		switch name {
+		case "Date":
+			return "kotlinx.datetime.LocalDate"
+		case "Timestamp":
+			return "kotlinx.datetime.Instant"
+		}
+	}
+	return scalarType(name)
+}
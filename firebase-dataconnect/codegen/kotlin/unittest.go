@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderDecodeTest writes a JVM unit test deserializing a sample JSON
+// payload -- derived from the operation's response shape -- into the
+// generated Data class. The test needs no emulator or network, so it runs
+// in plain unit test suites and catches serialization regressions when the
+// schema or the generator changes.
+func (g *Generator) renderDecodeTest(w *writer, m *operationModel) {
+	w.line("package %s", m.Package)
+	w.line("")
+	w.line("public class %sDecodeTest {", m.ClassName)
+	w.in()
+	w.line("@org.junit.Test")
+	w.line("public fun decodeSampleResponse() {")
+	w.in()
+	w.line("val json =")
+	w.in()
+	w.line(`"""`)
+	g.renderSampleJSON(w, m.Data)
+	w.line(`"""`)
+	w.out()
+	// The member overload with an explicit serializer is used because the
+	// one-argument decodeFromString extension does not resolve through a
+	// fully qualified receiver, and generated code carries no imports.
+	w.line("val data = kotlinx.serialization.json.Json.decodeFromString(")
+	w.in()
+	w.line("kotlinx.serialization.serializer<%s.Data>(), json)", m.ClassName)
+	w.out()
+	w.line("org.junit.Assert.assertNotNull(data)")
+	w.out()
+	w.line("}")
+	w.out()
+	w.line("}")
+}
+
+// renderSampleJSON writes a JSON object matching a response class: every
+// field present, nullable fields null, lists empty, scalars given a fixed
+// sample value.
+func (g *Generator) renderSampleJSON(w *writer, class *classModel) {
+	w.line("{")
+	w.in()
+	for i, field := range class.Fields {
+		comma := ","
+		if i == len(class.Fields)-1 {
+			comma = ""
+		}
+		w.line("%q: %s%s", field.Name, g.sampleValue(class, field.Type), comma)
+	}
+	w.out()
+	w.line("}")
+}
+
+// sampleValue returns the JSON literal used for one field of the sample
+// payload. The literal must decode into the field's Kotlin type: an enum
+// field gets its first declared value, and non-string scalars get a value
+// their serializer accepts.
+func (g *Generator) sampleValue(class *classModel, kotlinType string) string {
+	if strings.HasSuffix(kotlinType, "?") {
+		return "null"
+	}
+	if strings.HasPrefix(kotlinType, "List<") {
+		return "[]"
+	}
+	if def := g.usedEnums[kotlinType]; def != nil && len(def.EnumValues) > 0 {
+		return fmt.Sprintf("%q", def.EnumValues[0].Name)
+	}
+	switch kotlinType {
+	case "String":
+		return `"sample"`
+	case "Int", "Long":
+		return "1"
+	case "Double", "Float":
+		return "1.5"
+	case "Boolean":
+		return "true"
+	case "ByteArray":
+		// Base64 for "sample"; the Bytes scalar travels base64-encoded.
+		return `"c2FtcGxl"`
+	case "java.util.UUID":
+		return `"00000000-0000-4000-8000-000000000000"`
+	case "LatLng":
+		return `{"latitude": 1.5, "longitude": 1.5}`
+	case "kotlinx.datetime.LocalDate":
+		return `"2026-01-01"`
+	case "kotlinx.datetime.Instant":
+		return `"2026-01-01T00:00:00Z"`
+	}
+	for _, nested := range class.Nested {
+		if nested.Name == kotlinType {
+			var sample strings.Builder
+			g.writeSampleObject(&sample, nested)
+			return sample.String()
+		}
+	}
+	return `"sample"`
+}
+
+// writeSampleObject renders a nested object's sample on one line, keeping
+// the raw string compact.
+func (g *Generator) writeSampleObject(sample *strings.Builder, class *classModel) {
+	sample.WriteString("{")
+	for i, field := range class.Fields {
+		if i > 0 {
+			sample.WriteString(", ")
+		}
+		fmt.Fprintf(sample, "%q: %s", field.Name, g.sampleValue(class, field.Type))
+	}
+	sample.WriteString("}")
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kotlin
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generatedMarker identifies files this generator wrote, regardless of the
+// comment syntax of their header.
+const generatedMarker = "Generated by the Firebase Data Connect Kotlin code generator"
+
+// Verify checks the connector's previously generated files against the
+// current inputs instead of generating: every generated file must carry an
+// input checksum matching InputChecksum. A mismatch means the file was
+// generated from older inputs and is stale; build tooling can run this
+// before compiling to fail fast after a schema or operation edit.
+func (g *Generator) Verify() error {
+	var stale []string
+	checked := 0
+	check := func(path string) error {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(contents), generatedMarker) {
+			// A hand-written file living among the outputs.
+			return nil
+		}
+		checked++
+		switch recorded := recordedChecksum(string(contents)); {
+		case recorded == "":
+			stale = append(stale, path+": no recorded input checksum")
+		case recorded != g.InputChecksum:
+			stale = append(stale, fmt.Sprintf("%s: generated from inputs %s, current inputs are %s",
+				path, recorded, g.InputChecksum))
+		}
+		return nil
+	}
+	pkgDir := filepath.FromSlash(strings.ReplaceAll(g.Package, ".", "/"))
+	for _, sourceSet := range []string{"", "test", "androidTest"} {
+		root := filepath.Join(g.DestDir, sourceSet, pkgDir)
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			return check(path)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range []string{g.Connector + ".proto", g.Connector + "-proguard-rules.pro"} {
+		path := filepath.Join(g.DestDir, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := check(path); err != nil {
+			return err
+		}
+	}
+	if checked == 0 {
+		return fmt.Errorf("no generated files found beneath %s; generate before verifying", g.DestDir)
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("%d stale generated file(s):\n%s", len(stale), strings.Join(stale, "\n"))
+	}
+	return nil
+}
+
+// recordedChecksum extracts the input checksum stamped into a generated
+// file's header, or "" when the header carries none.
+func recordedChecksum(contents string) string {
+	for _, line := range strings.Split(contents, "\n") {
+		if _, after, ok := strings.Cut(line, "Input checksum: "); ok {
+			return strings.TrimSpace(after)
+		}
+	}
+	return ""
+}
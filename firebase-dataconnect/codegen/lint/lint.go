@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks a schema and a connector's operations for problems
+// that are not GraphQL validation errors but that a reviewer would flag.
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity is how a rule's findings are reported.
+type Severity int
+
+const (
+	// Off disables the rule.
+	Off Severity = iota
+	// Warning reports findings without failing generation.
+	Warning
+	// Error reports findings and fails generation.
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Off:
+		return "off"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is one finding of one rule.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Pos      *ast.Position
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	location := "(unknown location)"
+	if d.Pos != nil && d.Pos.Src != nil {
+		location = fmt.Sprintf("%s:%d", d.Pos.Src.Name, d.Pos.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", location, d.Severity, d.Message, d.Rule)
+}
+
+// Config maps rule names to severities, overriding the rules' defaults.
+type Config map[string]Severity
+
+// ParseConfig parses a comma-separated list of rule=severity pairs, e.g.
+// "operation-auth=error,unused-variable=off".
+func ParseConfig(s string) (Config, error) {
+	config := Config{}
+	if s == "" {
+		return config, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		name, severity, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("malformed lint setting %q: expected rule=severity", pair)
+		}
+		if ruleByName(name) == nil {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+		switch severity {
+		case "off":
+			config[name] = Off
+		case "warning":
+			config[name] = Warning
+		case "error":
+			config[name] = Error
+		default:
+			return nil, fmt.Errorf("unknown lint severity %q: expected off, warning, or error", severity)
+		}
+	}
+	return config, nil
+}
+
+// Check runs every enabled rule and returns the findings.
+func (c Config) Check(s *schema.Schema, operations []*schema.Operation) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, rule := range rules {
+		severity := rule.defaultSeverity
+		if configured, ok := c[rule.name]; ok {
+			severity = configured
+		}
+		if severity == Off {
+			continue
+		}
+		ctx := &context{
+			schema:     s,
+			operations: operations,
+			report: func(pos *ast.Position, format string, args ...any) {
+				diagnostics = append(diagnostics, Diagnostic{
+					Rule:     rule.name,
+					Severity: severity,
+					Pos:      pos,
+					Message:  fmt.Sprintf(format, args...),
+				})
+			},
+		}
+		rule.check(ctx)
+	}
+	return diagnostics
+}
+
+// HasErrors reports whether any diagnostic has Error severity.
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// context is what a rule's check function runs against.
+type context struct {
+	schema     *schema.Schema
+	operations []*schema.Operation
+	report     func(pos *ast.Position, format string, args ...any)
+}
+
+// rule is one lint check.
+type rule struct {
+	name            string
+	defaultSeverity Severity
+	check           func(ctx *context)
+}
+
+func ruleByName(name string) *rule {
+	for _, r := range rules {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
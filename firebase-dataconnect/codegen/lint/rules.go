@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// rules are all known rules, in the order they are run.
+var rules = []*rule{
+	{
+		name:            "operation-auth",
+		defaultSeverity: Warning,
+		check:           checkOperationAuth,
+	},
+	{
+		name:            "unused-variable",
+		defaultSeverity: Warning,
+		check:           checkUnusedVariables,
+	},
+	{
+		name:            "operation-naming",
+		defaultSeverity: Warning,
+		check:           checkOperationNaming,
+	},
+	{
+		name:            "operation-complexity",
+		defaultSeverity: Warning,
+		check:           checkOperationComplexity,
+	},
+}
+
+// namingPattern is the convention operation names must follow. The default
+// requires UpperCamelCase, which keeps generated class names consistent;
+// SetNamingPattern overrides it per connector.
+var namingPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// SetNamingPattern overrides the regular expression the operation-naming
+// rule enforces.
+func SetNamingPattern(pattern string) error {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("malformed naming pattern %q: %w", pattern, err)
+	}
+	namingPattern = compiled
+	return nil
+}
+
+// checkOperationNaming flags operation names that do not follow the
+// connector's naming convention.
+func checkOperationNaming(ctx *context) {
+	for _, op := range ctx.operations {
+		if !namingPattern.MatchString(op.Name) {
+			ctx.report(op.Position,
+				"%s name %q does not match the naming convention %s",
+				op.Operation, op.Name, namingPattern)
+		}
+	}
+}
+
+// checkOperationAuth flags operations that do not state their authorization
+// with an explicit @auth directive.
+func checkOperationAuth(ctx *context) {
+	for _, op := range ctx.operations {
+		if op.Directives.ForName("auth") == nil {
+			ctx.report(op.Position,
+				"%s %s does not declare @auth; state the required authorization explicitly",
+				op.Operation, op.Name)
+		}
+	}
+}
+
+// Complexity thresholds for the operation-complexity rule. Operations past
+// them generate enormous Kotlin classes and fetch heavy responses;
+// SetComplexityLimits overrides the defaults.
+var (
+	maxSelectionDepth = 10
+	maxSelectedFields = 100
+)
+
+// SetComplexityLimits overrides the selection depth and total selected field
+// count thresholds of the operation-complexity rule. Zero keeps a
+// threshold's current value.
+func SetComplexityLimits(depth, fields int) {
+	if depth > 0 {
+		maxSelectionDepth = depth
+	}
+	if fields > 0 {
+		maxSelectedFields = fields
+	}
+}
+
+// checkOperationComplexity flags operations whose selections nest deeper or
+// select more fields in total than the configured thresholds.
+func checkOperationComplexity(ctx *context) {
+	for _, op := range ctx.operations {
+		depth, fields := selectionComplexity(op.SelectionSet)
+		if depth > maxSelectionDepth {
+			ctx.report(op.Position,
+				"%s %s selects %d levels deep, past the threshold of %d",
+				op.Operation, op.Name, depth, maxSelectionDepth)
+		}
+		if fields > maxSelectedFields {
+			ctx.report(op.Position,
+				"%s %s selects %d fields in total, past the threshold of %d",
+				op.Operation, op.Name, fields, maxSelectedFields)
+		}
+	}
+}
+
+// selectionComplexity measures a selection set's nesting depth and total
+// field count, looking through fragments without adding depth for them.
+func selectionComplexity(selections ast.SelectionSet) (depth, fields int) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			fields++
+			childDepth, childFields := selectionComplexity(sel.SelectionSet)
+			fields += childFields
+			depth = max(depth, childDepth+1)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				childDepth, childFields := selectionComplexity(sel.Definition.SelectionSet)
+				fields += childFields
+				depth = max(depth, childDepth)
+			}
+		case *ast.InlineFragment:
+			childDepth, childFields := selectionComplexity(sel.SelectionSet)
+			fields += childFields
+			depth = max(depth, childDepth)
+		}
+	}
+	return depth, fields
+}
+
+// checkUnusedVariables flags non-null variables that are declared but never
+// referenced in the operation body: generation still forces callers to
+// supply them.
+func checkUnusedVariables(ctx *context) {
+	for _, op := range ctx.operations {
+		used := map[string]bool{}
+		collectDirectiveVariables(op.Directives, used)
+		collectSelectionVariables(op.SelectionSet, used)
+		for _, variable := range op.VariableDefinitions {
+			if variable.Type.NonNull && !used[variable.Variable] {
+				ctx.report(variable.Position,
+					"%s %s declares $%s: %s but never uses it; callers are still forced to supply it",
+					op.Operation, op.Name, variable.Variable, variable.Type.String())
+			}
+		}
+	}
+}
+
+func collectSelectionVariables(selections ast.SelectionSet, used map[string]bool) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			for _, arg := range sel.Arguments {
+				collectValueVariables(arg.Value, used)
+			}
+			collectDirectiveVariables(sel.Directives, used)
+			collectSelectionVariables(sel.SelectionSet, used)
+		case *ast.FragmentSpread:
+			collectDirectiveVariables(sel.Directives, used)
+			if sel.Definition != nil {
+				collectSelectionVariables(sel.Definition.SelectionSet, used)
+			}
+		case *ast.InlineFragment:
+			collectDirectiveVariables(sel.Directives, used)
+			collectSelectionVariables(sel.SelectionSet, used)
+		}
+	}
+}
+
+func collectDirectiveVariables(directives ast.DirectiveList, used map[string]bool) {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			collectValueVariables(arg.Value, used)
+		}
+	}
+}
+
+func collectValueVariables(value *ast.Value, used map[string]bool) {
+	if value == nil {
+		return
+	}
+	if value.Kind == ast.Variable {
+		used[value.Raw] = true
+	}
+	for _, child := range value.Children {
+		collectValueVariables(child.Value, used)
+	}
+}
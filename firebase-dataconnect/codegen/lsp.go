@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// lspServer speaks the subset of the Language Server Protocol that editor
+// GraphQL support needs from the generator: diagnostics as connector files
+// are edited, validated against the synthesized schema rather than the raw
+// sources, and hover for schema type names. Messages travel over stdio.
+type lspServer struct {
+	in        *bufio.Reader
+	out       io.Writer
+	schemaDir string
+
+	// docs holds the current text of each open document by URI.
+	docs map[string]string
+}
+
+// runLSP serves LSP requests over stdio until the client exits.
+func runLSP(in io.Reader, out io.Writer, schemaDir string) error {
+	server := &lspServer{
+		in:        bufio.NewReader(in),
+		out:       out,
+		schemaDir: schemaDir,
+		docs:      map[string]string{},
+	}
+	return server.serve()
+}
+
+// lspMessage is a JSON-RPC request or notification.
+type lspMessage struct {
+	ID     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (s *lspServer) serve() error {
+	for {
+		message, err := s.readMessage()
+		if err != nil {
+			return err
+		}
+		switch message.Method {
+		case "initialize":
+			s.respond(message.ID, map[string]any{
+				"capabilities": map[string]any{
+					// Full-document sync: connector files are small and it
+					// keeps the server stateless per change.
+					"textDocumentSync": 1,
+					"hoverProvider":    true,
+				},
+			})
+		case "shutdown":
+			s.respond(message.ID, nil)
+		case "exit":
+			return nil
+		case "textDocument/didOpen":
+			var params struct {
+				TextDocument struct {
+					URI  string `json:"uri"`
+					Text string `json:"text"`
+				} `json:"textDocument"`
+			}
+			if err := json.Unmarshal(message.Params, &params); err != nil {
+				continue
+			}
+			s.docs[params.TextDocument.URI] = params.TextDocument.Text
+			s.publishDiagnostics(params.TextDocument.URI)
+		case "textDocument/didChange":
+			var params struct {
+				TextDocument struct {
+					URI string `json:"uri"`
+				} `json:"textDocument"`
+				ContentChanges []struct {
+					Text string `json:"text"`
+				} `json:"contentChanges"`
+			}
+			if err := json.Unmarshal(message.Params, &params); err != nil {
+				continue
+			}
+			if len(params.ContentChanges) > 0 {
+				s.docs[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+				s.publishDiagnostics(params.TextDocument.URI)
+			}
+		case "textDocument/hover":
+			var params struct {
+				TextDocument struct {
+					URI string `json:"uri"`
+				} `json:"textDocument"`
+				Position lspPosition `json:"position"`
+			}
+			if err := json.Unmarshal(message.Params, &params); err != nil {
+				continue
+			}
+			s.respond(message.ID, s.hover(params.TextDocument.URI, params.Position))
+		default:
+			if message.ID != nil {
+				s.respond(message.ID, nil)
+			}
+		}
+	}
+}
+
+// publishDiagnostics validates a document against the synthesized schema
+// and pushes the outcome. The schema reload is cheap on unchanged schema
+// sources thanks to the content-hash cache.
+func (s *lspServer) publishDiagnostics(uri string) {
+	diagnostics := []lspDiagnostic{}
+	loadedSchema, err := schema.Load(s.schemaDir)
+	if err == nil {
+		_, err = schema.ValidateSource(loadedSchema, uri, s.docs[uri])
+	}
+	if err != nil {
+		diagnostics = lspDiagnostics(err)
+	}
+	s.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+// lspDiagnostics converts a validation error into LSP diagnostics, one per
+// gqlerror with its location when available.
+func lspDiagnostics(err error) []lspDiagnostic {
+	var list gqlerror.List
+	if !errors.As(err, &list) {
+		return []lspDiagnostic{{Severity: 1, Message: err.Error()}}
+	}
+	var diagnostics []lspDiagnostic
+	for _, gqlErr := range list {
+		diagnostic := lspDiagnostic{Severity: 1, Message: gqlErr.Message}
+		if len(gqlErr.Locations) > 0 {
+			// LSP positions are zero-based; gqlparser's are one-based.
+			position := lspPosition{
+				Line:      gqlErr.Locations[0].Line - 1,
+				Character: gqlErr.Locations[0].Column - 1,
+			}
+			diagnostic.Range = lspRange{Start: position, End: position}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// hover resolves the word under the cursor against the synthesized schema,
+// so connector authors see the fields and types that only exist after
+// synthesis.
+func (s *lspServer) hover(uri string, position lspPosition) any {
+	word := wordAt(s.docs[uri], position)
+	if word == "" {
+		return nil
+	}
+	loadedSchema, err := schema.Load(s.schemaDir)
+	if err != nil {
+		return nil
+	}
+	def := loadedSchema.Types[word]
+	if def == nil {
+		return nil
+	}
+	contents := fmt.Sprintf("%s %s", strings.ToLower(string(def.Kind)), def.Name)
+	if def.Description != "" {
+		contents += "\n\n" + def.Description
+	}
+	return map[string]any{"contents": contents}
+}
+
+// wordAt returns the identifier containing the given position.
+func wordAt(text string, position lspPosition) string {
+	lines := strings.Split(text, "\n")
+	if position.Line >= len(lines) {
+		return ""
+	}
+	line := lines[position.Line]
+	if position.Character > len(line) {
+		return ""
+	}
+	isWord := func(r byte) bool {
+		return r == '_' || unicode.IsLetter(rune(r)) || unicode.IsDigit(rune(r))
+	}
+	start, end := position.Character, position.Character
+	for start > 0 && isWord(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isWord(line[end]) {
+		end++
+	}
+	return line[start:end]
+}
+
+// readMessage reads one Content-Length framed JSON-RPC message.
+func (s *lspServer) readMessage() (*lspMessage, error) {
+	length := 0
+	for {
+		header, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		header = strings.TrimRight(header, "\r\n")
+		if header == "" {
+			break
+		}
+		if value, ok := strings.CutPrefix(header, "Content-Length: "); ok {
+			if length, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length %q", value)
+			}
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	message := &lspMessage{}
+	if err := json.Unmarshal(body, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (s *lspServer) respond(id *json.RawMessage, result any) {
+	s.writeMessage(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+func (s *lspServer) notify(method string, params any) {
+	s.writeMessage(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (s *lspServer) writeMessage(message any) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
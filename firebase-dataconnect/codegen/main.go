@@ -0,0 +1,557 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command codegen generates the Kotlin sources for a Firebase Data Connect
+// connector from the connector's GraphQL schema and operation definitions.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/backend"
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/kotlin"
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/lint"
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/schema"
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/stats"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "codegen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	schemaDir := flags.String("schema_dir", "",
+		"directory containing the schema's .gql files")
+	schemaURL := flags.String("schema_url", "",
+		"URL to fetch the schema .gql document from, instead of -schema_dir")
+	fetchTimeout := flags.Duration("fetch_timeout", 30*time.Second,
+		"timeout for each remote schema fetch attempt")
+	fetchRetries := flags.Int("fetch_retries", 2,
+		"how many times a failed remote schema fetch is retried")
+	fetchProxy := flags.String("fetch_proxy", "",
+		"HTTP proxy URL for remote schema fetches (defaults to the proxy environment variables)")
+	fetchCACert := flags.String("fetch_ca_cert", "",
+		"PEM file of additional CA certificates to trust for remote schema fetches")
+	preludeVersion := flags.String("prelude_version", "",
+		"embedded Data Connect prelude version to load schemas against; defaults to the newest")
+	preludeDir := flags.String("prelude_dir", "",
+		"directory of prelude .gql files to load instead of an embedded prelude")
+	preludeChannel := flags.String("prelude_channel", "",
+		"Data Connect release channel to download the prelude from: stable or preview")
+	preludePin := flags.String("prelude_pin", "",
+		"sha256: checksum the downloaded prelude must match")
+	offline := flags.Bool("offline", false,
+		"guarantee that no network access occurs; network-dependent flags become errors")
+	connectorDir := flags.String("connector_dir", "",
+		"comma-separated directories (or glob patterns such as connectors/**/*.gql) containing each connector's operation files")
+	dataconnectYAML := flags.String("dataconnect_yaml", "",
+		"path to the Firebase CLI's dataconnect.yaml; derives schema_dir, connector_dir, connector, and kotlin_package from the CLI project layout")
+	inputExtensions := flags.String("input_extensions", ".gql",
+		"comma-separated file extensions scanned for GraphQL sources, e.g. .gql,.graphql,.graphqls")
+	pluralOverrides := flags.String("plural_overrides", "",
+		"comma-separated singular=plural overrides for irregular nouns, e.g. person=people")
+	destDir := flags.String("dest_dir", "",
+		"directory to which the generated Kotlin sources are written")
+	kotlinPackage := flags.String("kotlin_package", "",
+		"comma-separated Kotlin packages of the generated sources, one per connector")
+	connector := flags.String("connector", "",
+		"comma-separated names of the connectors whose sources are being generated")
+	lintSettings := flags.String("lint", "",
+		"comma-separated rule=severity lint settings, e.g. operation-auth=error")
+	namingPattern := flags.String("naming_pattern", "",
+		"regular expression operation names must match for the operation-naming lint rule")
+	maxSelectionDepth := flags.Int("max_selection_depth", 0,
+		"selection depth threshold for the operation-complexity lint rule; 0 uses the default of 10")
+	maxSelectedFields := flags.Int("max_selected_fields", 0,
+		"total selected field threshold for the operation-complexity lint rule; 0 uses the default of 100")
+	language := flags.String("language", "kotlin",
+		"language backend to generate sources for")
+	serialization := flags.String("serialization", "",
+		"serialization library to annotate generated classes for: moshi or gson")
+	roomEntities := flags.Bool("room_entities", false,
+		"also generate a Room @Entity class per @table type")
+	dataStoreProto := flags.Bool("datastore_proto", false,
+		"also generate a proto3 schema for Proto DataStore from the @table types")
+	composePreviews := flags.Bool("compose_previews", false,
+		"also generate a Compose PreviewParameterProvider per operation")
+	liveData := flags.Bool("livedata", false,
+		"also generate an asLiveData extension per query")
+	rxJava := flags.Bool("rxjava", false,
+		"also generate an RxJava 3 asSingle extension per operation")
+	resultWrapper := flags.Bool("result_wrapper", false,
+		"also generate the OperationResult sealed class")
+	kotlinxDatetime := flags.Bool("kotlinx_datetime", false,
+		"map Date and Timestamp scalars to kotlinx-datetime types")
+	scalarMappings := flags.String("scalar_mappings", "",
+		"comma-separated Scalar=KotlinType overrides for custom scalars, e.g. UUID=java.util.UUID,Int64=Long")
+	valueClasses := flags.Bool("value_classes", false,
+		"generate inline value classes for single-field table keys")
+	templateHookDir := flags.String("template_hook_dir", "",
+		"directory whose optional header.gotmpl and footer.gotmpl are rendered around every generated file")
+	templateDir := flags.String("template_dir", "",
+		"directory whose optional operation.gotmpl replaces the built-in rendering of each operation's Kotlin file")
+	shardByKind := flags.Bool("shard_by_kind", false,
+		"generate queries and mutations into separate .queries and .mutations subpackages")
+	singleFile := flags.Bool("single_file", false,
+		"render all of a connector's operations into one Kotlin file instead of one file per operation")
+	seedScripts := flags.Bool("seed_scripts", false,
+		"also write emulator seed mutations and a runner manifest with a sample row per @table type")
+	connections := flags.Bool("connections", false,
+		"synthesize cursor-based connection query fields per table and generate the ConnectionPager helper")
+	authGuards := flags.Bool("auth_guards", false,
+		"also generate checkAuth preconditions that fail before the request when a signed-in user is missing")
+	unitTests := flags.Bool("unit_tests", false,
+		"also generate JVM unit tests deserializing sample JSON payloads into the generated Data classes")
+	emulatorTests := flags.Bool("emulator_tests", false,
+		"also generate androidTest skeletons executing each operation against the local emulator")
+	grpcStubs := flags.Bool("grpc_stubs", false,
+		"also generate typed wrappers over the low-level ExecuteQuery/ExecuteMutation gRPC surface")
+	connectorClass := flags.Bool("connector_class", false,
+		"also generate the <Connector>Connector class with one typed execute handle per operation")
+	proguardRules := flags.Bool("proguard_rules", false,
+		"also write R8/ProGuard keep rules for the generated classes' serialization machinery")
+	queryDSL := flags.Bool("query_dsl", false,
+		"also generate a connector-scoped builder DSL for composing ad-hoc list queries at runtime")
+	resourceOperations := flags.Bool("operations_as_resources", false,
+		"package operation GraphQL as resource files instead of string constants")
+	keepGoing := flags.Bool("keep_going", false,
+		"continue generating the remaining operations when one fails, reporting every failure at the end")
+	jobs := flags.Int("jobs", 0,
+		"how many operations render concurrently; 0 uses one worker per available CPU")
+	incremental := flags.Bool("incremental", false,
+		"skip rewriting generated files whose inputs are unchanged, tracked in a hash manifest beneath -dest_dir")
+	verify := flags.Bool("verify", false,
+		"instead of generating, fail if any previously generated file's recorded input checksum no longer matches the current inputs")
+	reproducible := flags.Bool("reproducible", false,
+		"after generating, generate a second time into a temporary directory and fail if any output differs")
+	maxDepth := flags.Int("max_depth", 0,
+		"maximum nesting depth of generated classes; 0 uses the default of 20")
+	maxConstructorParams := flags.Int("max_constructor_params", 0,
+		"maximum parameter count of flattening constructors; 0 uses the default of 254")
+	flattenDepth := flags.Int("flatten_depth", 0,
+		"levels of nested non-null input objects flattening constructors expand into parameters, passing deeper objects whole; 0 uses the default of 1")
+	noConvenienceConstructors := flags.Bool("no_convenience_constructors", false,
+		"omit the flattening convenience constructors; generated classes keep only their primary constructors")
+	builders := flags.Bool("builders", false,
+		"generate named companion builder functions, one per input-object variable, instead of flattening constructor overloads")
+	typedKeys := flags.Bool("typed_keys", false,
+		"generate one shared <Table>Key class per table, used for key variables and key-returning mutation selections")
+	dataMappers := flags.Bool("data_mappers", false,
+		"also generate extension functions converting response classes back into the input _Data classes they cover")
+	diffHelpers := flags.Bool("diff_helpers", false,
+		"also generate diff(key, old, new) helpers on update mutations, sending only the changed fields")
+	explainOperation := flags.String("explain", "",
+		"print the computed class tree of the named operation and exit without generating files")
+	explainSynthesis := flags.Bool("explain_synthesis", false,
+		"trace each synthesized type and field to stderr with the rule that produced it")
+	lspMode := flags.Bool("lsp", false,
+		"serve editor diagnostics and hover over stdio using a minimal LSP subset")
+	noColor := flags.Bool("no_color", false,
+		"disable ANSI colors in diagnostics")
+	printSummary := flags.Bool("summary", false,
+		"print an end-of-run summary of work done and time spent per phase")
+	summaryFile := flags.String("summary_file", "",
+		"also write the end-of-run summary as JSON to this file")
+	ideConfigDir := flags.String("ide_config_dir", "",
+		"directory to write a graphql.config.yml and an SDL dump of the synthesized schema for IDE GraphQL plugins")
+	statsFile := flags.String("stats_file", "",
+		"write fine-grained per-phase timings as JSON to this file")
+	flags.String("config", "",
+		"path to a config file, YAML (.yaml/.yml) or one 'name = value' per line, applied below env vars and flags")
+	printEffectiveConfig := flags.Bool("print_config", false,
+		"print the effective merged configuration with the origin of each value, then exit")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	sources, err := resolveConfig(flags)
+	if err != nil {
+		return err
+	}
+	if *dataconnectYAML != "" {
+		project, err := loadFirebaseProject(*dataconnectYAML)
+		if err != nil {
+			return err
+		}
+		// Derived values sit below every explicit layer: a flag, env var,
+		// or config file setting still wins.
+		for name, value := range project.flagValues() {
+			if sources[name] != "default" || value == "" {
+				continue
+			}
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("%s: %w", *dataconnectYAML, err)
+			}
+			sources[name] = "dataconnect.yaml " + *dataconnectYAML
+		}
+	}
+	if *printEffectiveConfig {
+		printConfig(os.Stdout, flags, sources)
+		return nil
+	}
+	if *lspMode {
+		if *schemaDir == "" {
+			return fmt.Errorf("-lsp requires -schema_dir")
+		}
+		return runLSP(os.Stdin, os.Stdout, *schemaDir)
+	}
+	for name, value := range map[string]string{
+		"connector_dir":  *connectorDir,
+		"dest_dir":       *destDir,
+		"kotlin_package": *kotlinPackage,
+		"connector":      *connector,
+	} {
+		if value == "" {
+			return fmt.Errorf("missing required flag: -%s", name)
+		}
+	}
+	if (*schemaDir == "") == (*schemaURL == "") {
+		return fmt.Errorf("exactly one of -schema_dir and -schema_url must be set")
+	}
+	if err := schema.SetFileExtensions(strings.Split(*inputExtensions, ",")); err != nil {
+		return err
+	}
+	schema.SetOffline(*offline)
+	schema.SetSynthesizeConnections(*connections)
+	if *pluralOverrides != "" {
+		overrides := map[string]string{}
+		for _, pair := range strings.Split(*pluralOverrides, ",") {
+			singular, plural, ok := strings.Cut(pair, "=")
+			if !ok || singular == "" || plural == "" {
+				return fmt.Errorf("invalid -plural_overrides entry %q: expected singular=plural", pair)
+			}
+			overrides[singular] = plural
+		}
+		schema.SetPluralOverrides(overrides)
+	}
+	preludeFlags := 0
+	for _, value := range []string{*preludeVersion, *preludeDir, *preludeChannel} {
+		if value != "" {
+			preludeFlags++
+		}
+	}
+	if preludeFlags > 1 {
+		return fmt.Errorf("at most one of -prelude_version, -prelude_dir, and -prelude_channel may be set")
+	}
+	if *preludeVersion != "" {
+		if err := schema.SetPreludeVersion(*preludeVersion); err != nil {
+			return err
+		}
+	}
+	if *preludeDir != "" {
+		schema.SetPreludeDir(*preludeDir)
+	}
+	if *preludeChannel != "" {
+		if err := schema.FetchPrelude(*preludeChannel, *preludePin, schema.FetchOptions{
+			Timeout:    *fetchTimeout,
+			Retries:    *fetchRetries,
+			ProxyURL:   *fetchProxy,
+			CACertFile: *fetchCACert,
+		}); err != nil {
+			return err
+		}
+	}
+	if *explainSynthesis {
+		schema.SetExplainSynthesis(os.Stderr)
+	}
+	if *offline && *schemaURL != "" {
+		return fmt.Errorf("-schema_url requires network access, which -offline forbids")
+	}
+
+	runSummary := &summary{}
+	var loadedSchema *schema.Schema
+	start := time.Now()
+	if *schemaURL != "" {
+		loadedSchema, err = schema.Fetch(*schemaURL, schema.FetchOptions{
+			Timeout:    *fetchTimeout,
+			Retries:    *fetchRetries,
+			ProxyURL:   *fetchProxy,
+			CACertFile: *fetchCACert,
+		})
+	} else {
+		loadedSchema, err = schema.Load(*schemaDir)
+	}
+	runSummary.phase("load schema", start)
+	if err != nil {
+		return err
+	}
+	lintConfig, err := lint.ParseConfig(*lintSettings)
+	if err != nil {
+		return err
+	}
+	if *namingPattern != "" {
+		if err := lint.SetNamingPattern(*namingPattern); err != nil {
+			return err
+		}
+	}
+	lint.SetComplexityLimits(*maxSelectionDepth, *maxSelectedFields)
+	term := newColors(*noColor)
+
+	switch *serialization {
+	case "", "moshi", "gson":
+	default:
+		return fmt.Errorf("unknown -serialization value %q: expected moshi or gson", *serialization)
+	}
+	if _, err := backend.New(*language); err != nil {
+		return err
+	}
+	var scalarTypeOverrides map[string]string
+	if *scalarMappings != "" {
+		scalarTypeOverrides = map[string]string{}
+		for _, pair := range strings.Split(*scalarMappings, ",") {
+			scalar, kotlinType, ok := strings.Cut(pair, "=")
+			if !ok || scalar == "" || kotlinType == "" {
+				return fmt.Errorf("invalid -scalar_mappings entry %q: expected Scalar=KotlinType", pair)
+			}
+			scalarTypeOverrides[scalar] = kotlinType
+		}
+	}
+
+	// Several connectors against the same schema may be generated in one
+	// invocation, sharing the parsed and synthesized schema above instead of
+	// reloading it per connector. The three per-connector flags are parallel
+	// lists.
+	connectors := strings.Split(*connector, ",")
+	connectorDirs := strings.Split(*connectorDir, ",")
+	kotlinPackages := strings.Split(*kotlinPackage, ",")
+	if len(connectorDirs) != len(connectors) || len(kotlinPackages) != len(connectors) {
+		return fmt.Errorf("-connector, -connector_dir, and -kotlin_package must list the same number of connectors")
+	}
+	if *explainOperation != "" && len(connectors) > 1 {
+		return fmt.Errorf("-explain requires a single connector")
+	}
+	if *ideConfigDir != "" {
+		if err := writeIDEConfig(*ideConfigDir, loadedSchema, connectorDirs); err != nil {
+			return err
+		}
+	}
+
+	var generateErr error
+	for i, name := range connectors {
+		start = time.Now()
+		operations, err := schema.LoadOperations(loadedSchema, connectorDirs[i])
+		runSummary.phase("load operations", start)
+		if err != nil {
+			return err
+		}
+		runSummary.Operations += len(operations)
+
+		start = time.Now()
+		diagnostics := lintConfig.Check(loadedSchema, operations)
+		runSummary.phase("lint", start)
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(os.Stderr, formatDiagnostic(term, diagnostic))
+		}
+		runSummary.Warnings += countSeverity(diagnostics, lint.Warning)
+		runSummary.Errors += countSeverity(diagnostics, lint.Error)
+		if lint.HasErrors(diagnostics) {
+			return fmt.Errorf("%d lint error(s)", countSeverity(diagnostics, lint.Error))
+		}
+
+		// Operations marked @noCodegen have been validated and linted like
+		// any other, but are not handed to the backend.
+		operations = schema.Generated(operations)
+
+		b, err := backend.New(*language)
+		if err != nil {
+			return err
+		}
+		generator, isKotlin := b.(*kotlin.Generator)
+		if !isKotlin {
+			// Kotlin-specific flags only apply to the kotlin backend; other
+			// backends are configured through their own flags as they grow.
+			start = time.Now()
+			if err := b.Generate(loadedSchema, operations); err != nil {
+				generateErr = errors.Join(generateErr, err)
+				runSummary.Errors++
+			}
+			runSummary.phase("generate", start)
+			continue
+		}
+		config := kotlin.Generator{
+			Package:                   kotlinPackages[i],
+			Connector:                 name,
+			DestDir:                   *destDir,
+			Serialization:             *serialization,
+			RoomEntities:              *roomEntities,
+			DataStoreProto:            *dataStoreProto,
+			ComposePreviews:           *composePreviews,
+			LiveData:                  *liveData,
+			RxJava:                    *rxJava,
+			ResultWrapper:             *resultWrapper,
+			KotlinxDatetime:           *kotlinxDatetime,
+			ScalarMappings:            scalarTypeOverrides,
+			ValueClasses:              *valueClasses,
+			TypedKeys:                 *typedKeys,
+			DataMappers:               *dataMappers,
+			DiffHelpers:               *diffHelpers,
+			ShardByKind:               *shardByKind,
+			SingleFile:                *singleFile,
+			SeedScripts:               *seedScripts,
+			Connections:               *connections,
+			AuthGuards:                *authGuards,
+			UnitTests:                 *unitTests,
+			EmulatorTests:             *emulatorTests,
+			GrpcStubs:                 *grpcStubs,
+			ConnectorClass:            *connectorClass,
+			ProguardRules:             *proguardRules,
+			QueryDSL:                  *queryDSL,
+			ResourceOperations:        *resourceOperations,
+			InputChecksum:             schema.Checksum(loadedSchema, operations, *schemaDir, connectorDirs[i]),
+			TemplateHookDir:           *templateHookDir,
+			TemplateDir:               *templateDir,
+			MaxDepth:                  *maxDepth,
+			MaxFlattenedParams:        *maxConstructorParams,
+			FlattenDepth:              *flattenDepth,
+			NoConvenienceConstructors: *noConvenienceConstructors,
+			Builders:                  *builders,
+			KeepGoing:                 *keepGoing,
+			Jobs:                      *jobs,
+			Incremental:               *incremental,
+		}
+		*generator = config
+		if *explainOperation != "" {
+			return generator.Explain(os.Stdout, loadedSchema, operations, *explainOperation)
+		}
+		if *verify {
+			start = time.Now()
+			if err := generator.Verify(); err != nil {
+				generateErr = errors.Join(generateErr, err)
+				runSummary.Errors++
+			}
+			runSummary.phase("verify", start)
+			continue
+		}
+		start = time.Now()
+		err = generator.Generate(loadedSchema, operations)
+		runSummary.phase("generate", start)
+		if err == nil && *reproducible {
+			err = checkReproducible(config, loadedSchema, operations)
+		}
+		if err != nil {
+			generateErr = errors.Join(generateErr, err)
+			runSummary.Errors++
+		}
+		runSummary.FilesWritten += generator.FilesWritten
+		runSummary.FilesSkipped += generator.FilesSkipped
+		runSummary.FilesPruned += generator.FilesPruned
+	}
+	if *printSummary {
+		runSummary.print(os.Stderr)
+	}
+	if *summaryFile != "" {
+		if err := runSummary.writeJSON(*summaryFile); err != nil {
+			return err
+		}
+	}
+	if *statsFile != "" {
+		if err := stats.WriteFile(*statsFile); err != nil {
+			return err
+		}
+	}
+	return generateErr
+}
+
+// checkReproducible reruns generation into a temporary directory and
+// compares every file it produces against the first run's output, failing
+// when any differs. Generating twice catches nondeterminism -- map
+// iteration order, unstable hashes, wall-clock stamps -- that a single run
+// cannot see.
+func checkReproducible(config kotlin.Generator, s *schema.Schema, operations []*schema.Operation) error {
+	tempDir, err := os.MkdirTemp("", "codegen-reproducible-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	second := config
+	second.DestDir = tempDir
+	second.Incremental = false
+	if err := second.Generate(s, operations); err != nil {
+		return fmt.Errorf("reproducibility check: second run failed: %w", err)
+	}
+	var differing []string
+	err = filepath.Walk(tempDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(tempDir, path)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(filepath.Base(rel), ".") {
+			// The registry, manifest, and descriptor dotfiles merge state
+			// from earlier runs and other connectors; only generated outputs
+			// must be identical.
+			return nil
+		}
+		rerun, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		first, err := os.ReadFile(filepath.Join(config.DestDir, rel))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(first, rerun) {
+			differing = append(differing, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(differing) > 0 {
+		return fmt.Errorf(
+			"reproducibility check failed: %d file(s) differ between two runs over identical inputs: %s",
+			len(differing), strings.Join(differing, ", "))
+	}
+	return nil
+}
+
+// formatDiagnostic renders a lint diagnostic with its severity colored by
+// level when the terminal supports it.
+func formatDiagnostic(term colors, d lint.Diagnostic) string {
+	severity := d.Severity.String()
+	switch d.Severity {
+	case lint.Error:
+		severity = term.red(severity)
+	case lint.Warning:
+		severity = term.yellow(severity)
+	}
+	location := "(unknown location)"
+	if d.Pos != nil && d.Pos.Src != nil {
+		location = fmt.Sprintf("%s:%d", d.Pos.Src.Name, d.Pos.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", term.bold(location), severity, d.Message, d.Rule)
+}
+
+func countSeverity(diagnostics []lint.Diagnostic, severity lint.Severity) int {
+	count := 0
+	for _, d := range diagnostics {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
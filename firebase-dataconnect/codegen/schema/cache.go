@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// schemaCache memoizes parsed and synthesized schemas by the content of
+// their sources, prelude included. A one-shot generation run loads the
+// schema once and never hits the cache, but watch and serve modes reload
+// on every change; keying by content means an edit that only touches
+// operation files reuses the previous schema instead of paying the full
+// parse, validation, and synthesis cost again on every keystroke.
+var (
+	schemaCacheMu sync.Mutex
+	schemaCache   = map[string]*Schema{}
+)
+
+// sourcesKey hashes the names and contents of the sources a schema is
+// loaded from.
+func sourcesKey(sources []*ast.Source) string {
+	h := sha256.New()
+	for _, source := range sources {
+		fmt.Fprintf(h, "%s\x00%s\x00", source.Name, source.Input)
+	}
+	return string(h.Sum(nil))
+}
+
+func cachedSchema(key string) *Schema {
+	schemaCacheMu.Lock()
+	defer schemaCacheMu.Unlock()
+	return schemaCache[key]
+}
+
+func storeSchema(key string, s *Schema) {
+	schemaCacheMu.Lock()
+	defer schemaCacheMu.Unlock()
+	schemaCache[key] = s
+}
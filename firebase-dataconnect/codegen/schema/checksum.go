@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Checksum returns a short, stable hash over every input the generator
+// consumed: the schema sources and the operations. Source paths are hashed
+// relative to their roots, so the same inputs hash the same on every
+// machine no matter where the tree is checked out. Stamped into generated
+// file headers, it lets build tooling detect stale outputs without parsing
+// them.
+func Checksum(s *Schema, operations []*Operation, schemaRoot, operationsRoot string) string {
+	hash := sha256.New()
+	type namedSource struct {
+		name   string
+		source *ast.Source
+	}
+	sources := make([]namedSource, 0, len(s.Sources))
+	for _, source := range s.Sources {
+		sources = append(sources, namedSource{relativeName(schemaRoot, source.Name), source})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].name < sources[j].name })
+	for _, source := range sources {
+		fmt.Fprintf(hash, "schema %s %d\n%s\n", source.name, len(source.source.Input), source.source.Input)
+	}
+	for _, op := range operations {
+		fmt.Fprintf(hash, "operation %s %s\n%s\n",
+			relativeName(operationsRoot, op.SourcePath), op.Name, op.Source)
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)[:6])
+}
+
+// relativeName returns name relative to root, falling back to the base name
+// when name does not sit beneath root -- remote schemas, glob patterns, and
+// embedded preludes have no meaningful root. The hash therefore never
+// includes a machine-specific absolute prefix.
+func relativeName(root, name string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, name); err == nil &&
+			rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return path.Base(filepath.ToSlash(name))
+}
+
+// DocumentChecksum returns the same short hash form for a single document,
+// as used to pin a fetched prelude.
+func DocumentChecksum(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return fmt.Sprintf("sha256:%x", sum[:6])
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// CommentBefore returns the contiguous block of '#' comment lines
+// immediately above pos, with the comment markers stripped, or "" when the
+// definition at pos has no preceding comment. The parser discards comments,
+// so they are recovered from the source the position points into.
+func CommentBefore(pos *ast.Position) string {
+	if pos == nil || pos.Src == nil || pos.Src.Input == "" {
+		return ""
+	}
+	lines := strings.Split(pos.Src.Input, "\n")
+	if pos.Line < 1 || pos.Line > len(lines) {
+		return ""
+	}
+	var comments []string
+	for i := pos.Line - 2; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		comments = append([]string{strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))}, comments...)
+	}
+	return strings.Join(comments, "\n")
+}
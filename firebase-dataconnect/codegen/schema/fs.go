@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// sourceFS, when set, replaces host filesystem access when scanning schema
+// and connector directories.
+var sourceFS fs.FS
+
+// SetFS routes directory scanning through fsys instead of the host
+// filesystem. WebAssembly builds have no usable os-level file access, so
+// hosts like the Firebase CLI's Node toolchain supply a virtual filesystem
+// and run the same generation logic unchanged. Pass nil to restore host
+// filesystem access.
+func SetFS(fsys fs.FS) {
+	sourceFS = fsys
+}
+
+// readGQLFilesFS is readGQLFiles over the pluggable filesystem. fs.FS has
+// no symlink semantics, so unlike the host-filesystem scan there is no link
+// following or cycle breaking to do.
+func readGQLFilesFS(dir string) ([]*ast.Source, error) {
+	var sources []*ast.Source
+	err := fs.WalkDir(sourceFS, dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !hasGQLExtension(entry.Name()) {
+			return nil
+		}
+		contents, err := fs.ReadFile(sourceFS, path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, &ast.Source{Name: path, Input: string(contents)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", strings.Join(fileExtensions, "/"), dir)
+	}
+	return sources, nil
+}
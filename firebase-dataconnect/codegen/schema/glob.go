@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// globMeta are the characters that make a connector location a glob
+// pattern rather than a plain directory.
+const globMeta = "*?["
+
+// globSources returns a source per file matching the given pattern, which
+// may use ** to span any number of directories, e.g. connectors/**/*.gql.
+// Matches are read in sorted path order so output never depends on
+// directory enumeration order.
+func globSources(pattern string) ([]*ast.Source, error) {
+	matches, err := expandGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	var sources []*ast.Source
+	for _, match := range matches {
+		contents, err := os.ReadFile(match)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &ast.Source{Name: match, Input: string(contents)})
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	return sources, nil
+}
+
+// expandGlob returns the files matching pattern. Unlike filepath.Glob it
+// understands the ** segment, which matches any number of directories,
+// including none.
+func expandGlob(pattern string) ([]string, error) {
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	start := "."
+	if filepath.IsAbs(pattern) {
+		start = string(filepath.Separator)
+		segments = segments[1:]
+	}
+	var matches []string
+	if err := globWalk(start, segments, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// globWalk appends the files beneath dir matching the remaining pattern
+// segments. Directories that cannot be read simply contribute no matches,
+// mirroring filepath.Glob.
+func globWalk(dir string, segments []string, matches *[]string) error {
+	if len(segments) == 0 {
+		if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+			*matches = append(*matches, dir)
+		}
+		return nil
+	}
+	segment, rest := segments[0], segments[1:]
+	if segment == "**" {
+		// ** matches zero segments here, or descends one directory and
+		// matches again.
+		if err := globWalk(dir, rest, matches); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				if err := globWalk(filepath.Join(dir, entry.Name()), segments, matches); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if !strings.ContainsAny(segment, globMeta) {
+		return globWalk(filepath.Join(dir, segment), rest, matches)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		matched, err := path.Match(segment, entry.Name())
+		if err != nil {
+			return fmt.Errorf("invalid glob pattern segment %q: %w", segment, err)
+		}
+		if matched {
+			if err := globWalk(filepath.Join(dir, entry.Name()), rest, matches); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
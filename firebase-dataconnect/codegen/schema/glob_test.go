@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestExpandGlobDoubleStar checks that ** spans any number of directories,
+// including none, and that non-matching files are left out.
+func TestExpandGlobDoubleStar(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"queries.gql",
+		"posts/mutations.gql",
+		"posts/drafts/archive.gql",
+		"posts/readme.txt",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	matches, err := expandGlob(filepath.Join(root, "**", "*.gql"))
+	if err != nil {
+		t.Fatalf("expandGlob() failed: %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "posts", "drafts", "archive.gql"),
+		filepath.Join(root, "posts", "mutations.gql"),
+		filepath.Join(root, "queries.gql"),
+	}
+	sort.Strings(matches)
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("expandGlob() = %v, want %v", matches, want)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/stats"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Operation is one named query or mutation defined by a connector.
+type Operation struct {
+	*ast.OperationDefinition
+
+	// Document is the query document the operation was parsed from, carrying
+	// any fragments defined alongside it.
+	Document *ast.QueryDocument
+
+	// SourcePath is the .gql file the operation was read from, and Source is
+	// the operation's own text within that file.
+	SourcePath string
+	Source     string
+
+	// Doc is the comment block immediately preceding the operation in its
+	// .gql file, if any.
+	Doc string
+}
+
+// LoadOperations parses and validates every GraphQL file beneath dir against
+// the schema and returns the operations they define. dir may also be a glob
+// pattern such as connectors/**/*.gql, in which case exactly the matching
+// files are loaded.
+func LoadOperations(s *Schema, dir string) ([]*Operation, error) {
+	var sources []*ast.Source
+	var err error
+	if strings.ContainsAny(dir, globMeta) {
+		sources, err = globSources(dir)
+	} else {
+		sources, err = readGQLFiles(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadOperations(s, sources)
+}
+
+// loadOperations parses and validates operation sources against the schema.
+// Files are validated concurrently -- the schema is only read -- but results
+// and diagnostics are assembled in source order, so output is identical to a
+// serial run regardless of scheduling.
+func loadOperations(s *Schema, sources []*ast.Source) ([]*Operation, error) {
+	sourceOps := make([][]*Operation, len(sources))
+	sourceErrs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source *ast.Source) {
+			defer wg.Done()
+			done := stats.Time("validate " + source.Name)
+			sourceOps[i], sourceErrs[i] = sourceOperations(s, source)
+			done()
+		}(i, source)
+	}
+	wg.Wait()
+	if err := errors.Join(sourceErrs...); err != nil {
+		return nil, err
+	}
+	var operations []*Operation
+	for _, ops := range sourceOps {
+		operations = append(operations, ops...)
+	}
+	return operations, nil
+}
+
+// ValidateSource parses and validates a single operations document against
+// the schema, for editor tooling that revalidates unsaved buffers.
+func ValidateSource(s *Schema, name, input string) ([]*Operation, error) {
+	return sourceOperations(s, &ast.Source{Name: name, Input: input})
+}
+
+// sourceOperations parses and validates the operations of a single source.
+// The source is parsed under its own name -- not gqlparser.LoadQuery's
+// anonymous "input" -- so every ast.Position, and every diagnostic derived
+// from one, carries the real file path.
+func sourceOperations(s *Schema, source *ast.Source) ([]*Operation, error) {
+	doc, err := parser.ParseQuery(source)
+	if err != nil {
+		var gqlErr *gqlerror.Error
+		if errors.As(err, &gqlErr) {
+			return nil, s.suggestNearestNames(gqlerror.List{gqlErr})
+		}
+		return nil, fmt.Errorf("%s: %w", source.Name, err)
+	}
+	if errs := validator.Validate(s.Schema, doc); len(errs) > 0 {
+		return nil, s.suggestNearestNames(errs)
+	}
+	var operations []*Operation
+	for _, op := range doc.Operations {
+		if op.Name == "" {
+			return nil, fmt.Errorf("%s: anonymous operations are not supported", source.Name)
+		}
+		if op.Operation == ast.Subscription {
+			return nil, fmt.Errorf("%s: subscription %s: subscriptions are not supported",
+				source.Name, op.Name)
+		}
+		if err := validateOperationArguments(s, op); err != nil {
+			return nil, err
+		}
+		operations = append(operations, &Operation{
+			OperationDefinition: op,
+			Document:            doc,
+			SourcePath:          source.Name,
+			Source:              operationSource(source.Input, op, doc),
+			Doc:                 CommentBefore(op.Position),
+		})
+	}
+	return operations, nil
+}
+
+// Generated filters out operations marked @noCodegen: they are parsed and
+// validated like any other, so server-only or experimental operations can
+// live alongside generated ones, but no code is generated for them.
+func Generated(operations []*Operation) []*Operation {
+	var generated []*Operation
+	for _, op := range operations {
+		if op.Directives.ForName("noCodegen") == nil {
+			generated = append(generated, op)
+		}
+	}
+	return generated
+}
+
+// operationSource extracts the text of a single operation from the file it
+// was defined in. When a file defines several operations, each is cut at the
+// start of the next definition.
+func operationSource(input string, op *ast.OperationDefinition, doc *ast.QueryDocument) string {
+	starts := []int{len(input)}
+	for _, other := range doc.Operations {
+		if other != op && other.Position != nil {
+			starts = append(starts, other.Position.Start)
+		}
+	}
+	sort.Ints(starts)
+	start := 0
+	if op.Position != nil {
+		start = op.Position.Start
+	}
+	end := len(input)
+	for _, candidate := range starts {
+		if candidate > start {
+			end = candidate
+			break
+		}
+	}
+	return strings.TrimSpace(input[start:end])
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Several prelude versions are embedded so that connectors written against
+// older backend schema semantics keep generating while new features land in
+// newer preludes.
+//
+//go:embed preludes
+var preludeFS embed.FS
+
+// defaultPreludeVersion is the newest embedded prelude, used unless
+// SetPreludeVersion selects another.
+const defaultPreludeVersion = "v2"
+
+var preludeVersion = defaultPreludeVersion
+
+// preludeVersionSet records whether SetPreludeVersion was called, in which
+// case it wins over a schema-version pragma.
+var preludeVersionSet bool
+
+// preludeDir, when set, loads the prelude from a directory of .gql files
+// instead of the embedded preludes.
+var preludeDir string
+
+// fetchedPrelude, when set by FetchPrelude, is the prelude downloaded from a
+// release channel.
+var fetchedPrelude *ast.Source
+
+// SetPreludeDir loads prelude definitions from the GraphQL files beneath dir
+// instead of an embedded prelude, for testing against modified or
+// pre-release prelude definitions. It takes precedence over
+// SetPreludeVersion.
+func SetPreludeDir(dir string) {
+	preludeDir = dir
+}
+
+// preludeSources returns the prelude as parser sources: the files of the
+// prelude directory when one is set, then a prelude fetched from a release
+// channel, and otherwise an embedded prelude. schemaVersion, when non-empty,
+// is the version declared by the schema's pragma; it selects the matching
+// embedded prelude unless an explicit SetPreludeVersion overrides it.
+func preludeSources(schemaVersion string) ([]*ast.Source, error) {
+	if preludeDir != "" {
+		sources, err := readGQLFiles(preludeDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			source.BuiltIn = true
+		}
+		return sources, nil
+	}
+	if fetchedPrelude != nil {
+		return []*ast.Source{fetchedPrelude}, nil
+	}
+	version := preludeVersion
+	if schemaVersion != "" && !preludeVersionSet {
+		version = schemaVersionPreludes[schemaVersion]
+	}
+	return []*ast.Source{preludeSource(version)}, nil
+}
+
+// PreludeVersions returns the embedded prelude versions, oldest first.
+func PreludeVersions() []string {
+	entries, err := preludeFS.ReadDir("preludes")
+	if err != nil {
+		panic(err)
+	}
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".gql"))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// SetPreludeVersion selects which embedded prelude schemas are loaded
+// against.
+func SetPreludeVersion(version string) error {
+	if _, err := preludeFS.ReadFile(preludePath(version)); err != nil {
+		return fmt.Errorf("unknown prelude version %q: embedded versions are %s",
+			version, strings.Join(PreludeVersions(), ", "))
+	}
+	preludeVersion = version
+	preludeVersionSet = true
+	return nil
+}
+
+// preludeSource returns an embedded prelude as a parser source. It is
+// marked built-in so that its definitions do not show up in user-facing
+// listings.
+func preludeSource(version string) *ast.Source {
+	contents, err := preludeFS.ReadFile(preludePath(version))
+	if err != nil {
+		// Unreachable: every caller passes a vetted version.
+		panic(err)
+	}
+	return &ast.Source{
+		Name:    "prelude-" + version + ".gql",
+		Input:   string(contents),
+		BuiltIn: true,
+	}
+}
+
+func preludePath(version string) string {
+	return "preludes/" + version + ".gql"
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// FetchOptions control the HTTP behavior of remote schema fetches.
+type FetchOptions struct {
+	// Timeout bounds each individual fetch attempt.
+	Timeout time.Duration
+
+	// Retries is how many times a failed attempt is retried. Connection
+	// errors and 5xx responses are retried with exponential backoff; 4xx
+	// responses fail immediately, since retrying cannot help.
+	Retries int
+
+	// ProxyURL routes fetches through an HTTP proxy. When empty, the
+	// standard proxy environment variables apply.
+	ProxyURL string
+
+	// CACertFile is a PEM file of additional CA certificates to trust,
+	// for fetching from servers behind corporate TLS interception.
+	CACertFile string
+}
+
+// transport builds the HTTP transport the options describe.
+func (opts FetchOptions) transport() (*http.Transport, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("malformed proxy URL %q: %w", opts.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s contains no usable CA certificates", opts.CACertFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return transport, nil
+}
+
+// offline, when set, makes every network access in this package fail
+// instead of touching the network.
+var offline bool
+
+// SetOffline guarantees that no code path in this package reaches the
+// network: any attempted fetch fails with an explanatory error.
+func SetOffline(v bool) {
+	offline = v
+}
+
+// Fetch downloads the schema .gql document at url and loads it.
+func Fetch(url string, opts FetchOptions) (*Schema, error) {
+	body, err := fetchDocument(url, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching schema from %s: %w", url, err)
+	}
+	return load([]*ast.Source{{Name: url, Input: body}})
+}
+
+// fetchDocument downloads the document at url, retrying per the options.
+func fetchDocument(url string, opts FetchOptions) (string, error) {
+	if offline {
+		return "", fmt.Errorf("running in offline mode")
+	}
+	transport, err := opts.transport()
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{Timeout: opts.Timeout, Transport: transport}
+	var lastErr error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep((500 * time.Millisecond) << (attempt - 1))
+		}
+		body, retryable, err := fetchOnce(client, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return "", lastErr
+}
+
+// preludeChannels maps Data Connect release channels to the prelude document
+// each one serves.
+var preludeChannels = map[string]string{
+	"stable":  "https://dataconnect.googleapis.com/codegen/prelude/stable.gql",
+	"preview": "https://dataconnect.googleapis.com/codegen/prelude/preview.gql",
+}
+
+// FetchPrelude downloads the prelude a release channel currently serves and
+// uses it for subsequent schema loads, so generation never drifts behind the
+// directives the server supports. The document is cached under the user
+// cache directory, and the cached copy is used when the endpoint cannot be
+// reached or in offline mode. pin, when non-empty, is a "sha256:" checksum
+// the document must match.
+func FetchPrelude(channel, pin string, opts FetchOptions) error {
+	url, ok := preludeChannels[channel]
+	if !ok {
+		var channels []string
+		for name := range preludeChannels {
+			channels = append(channels, name)
+		}
+		sort.Strings(channels)
+		return fmt.Errorf("unknown prelude channel %q: known channels are %s",
+			channel, strings.Join(channels, ", "))
+	}
+	cachePath, cacheErr := preludeCachePath(channel)
+	var body string
+	var fetchErr error
+	if offline {
+		fetchErr = fmt.Errorf("running in offline mode")
+	} else {
+		body, fetchErr = fetchDocument(url, opts)
+	}
+	if fetchErr != nil {
+		if cacheErr != nil {
+			return fmt.Errorf("fetching prelude from the %s channel: %w", channel, fetchErr)
+		}
+		cached, err := os.ReadFile(cachePath)
+		if err != nil {
+			return fmt.Errorf("fetching prelude from the %s channel: %w (and no cached copy)",
+				channel, fetchErr)
+		}
+		body = string(cached)
+	}
+	if pin != "" && DocumentChecksum(body) != pin {
+		return fmt.Errorf("prelude from the %s channel has checksum %s, which does not match the pin %s",
+			channel, DocumentChecksum(body), pin)
+	}
+	if fetchErr == nil && cacheErr == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o777); err == nil {
+			// A stale cache is only a fallback; failing to write it is not
+			// worth failing the run.
+			_ = os.WriteFile(cachePath, []byte(body), 0o666)
+		}
+	}
+	fetchedPrelude = &ast.Source{
+		Name:    "prelude-" + channel + ".gql",
+		Input:   body,
+		BuiltIn: true,
+	}
+	return nil
+}
+
+// preludeCachePath returns where a channel's prelude is cached locally.
+func preludeCachePath(channel string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "firebase-dataconnect-codegen", "prelude-"+channel+".gql"), nil
+}
+
+// fetchOnce performs one fetch attempt, reporting whether a failure is
+// worth retrying.
+func fetchOnce(client *http.Client, url string) (body string, retryable bool, err error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode >= 500, fmt.Errorf("server returned %s", resp.Status)
+	}
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, err
+	}
+	return string(contents), false, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema loads a Data Connect schema and the operations a connector
+// defines against it. Loading a schema also synthesizes the parts that the
+// Data Connect backend derives from the declared @table types: key and data
+// input types and the per-table query and mutation fields.
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/firebase/firebase-android-sdk/firebase-dataconnect/codegen/stats"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Schema is a Data Connect schema after synthesis.
+type Schema struct {
+	*ast.Schema
+
+	// Tables are the object types declared with @table.
+	Tables []*ast.Definition
+
+	// Sources are the user-authored schema sources the schema was loaded
+	// from, excluding the prelude.
+	Sources []*ast.Source
+}
+
+// fileExtensions are the file name extensions recognized when scanning a
+// schema or connector directory.
+var fileExtensions = []string{".gql"}
+
+// SetFileExtensions replaces the file name extensions recognized when
+// scanning directories. Each extension must start with a dot.
+func SetFileExtensions(extensions []string) error {
+	if len(extensions) == 0 {
+		return fmt.Errorf("at least one input file extension is required")
+	}
+	for _, extension := range extensions {
+		if !strings.HasPrefix(extension, ".") || len(extension) == 1 {
+			return fmt.Errorf("invalid input file extension %q: expected e.g. .gql or .graphql", extension)
+		}
+	}
+	fileExtensions = extensions
+	return nil
+}
+
+// Load reads every GraphQL file in dir, parses it together with the prelude,
+// and synthesizes the derived parts of the schema.
+func Load(dir string) (*Schema, error) {
+	sources, err := readGQLFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return load(sources)
+}
+
+// load parses the given schema sources together with the prelude and
+// synthesizes the derived parts of the schema.
+func load(sources []*ast.Source) (*Schema, error) {
+	userSources := sources
+	version, err := declaredSchemaVersion(userSources)
+	if err != nil {
+		return nil, err
+	}
+	done := stats.Time("prelude load")
+	prelude, err := preludeSources(version)
+	done()
+	if err != nil {
+		return nil, err
+	}
+	sources = append(prelude, sources...)
+	key := sourcesKey(sources)
+	if cached := cachedSchema(key); cached != nil {
+		return cached, nil
+	}
+	done = stats.Time("schema parse")
+	astSchema, err := gqlparser.LoadSchema(sources...)
+	done()
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{Schema: astSchema, Sources: userSources}
+	if err := validateDefinitions(s); err != nil {
+		return nil, err
+	}
+	done = stats.Time("synthesis")
+	err = s.synthesize()
+	done()
+	if err != nil {
+		return nil, err
+	}
+	storeSchema(key, s)
+	return s, nil
+}
+
+// readGQLFiles returns a source for each file beneath dir whose name ends in
+// one of the recognized extensions. Subdirectories are scanned recursively
+// and symlinks are followed, so monorepos that assemble connector sources via
+// links work; a directory reached through several links is only read once.
+func readGQLFiles(dir string) ([]*ast.Source, error) {
+	if sourceFS != nil {
+		return readGQLFilesFS(dir)
+	}
+	var sources []*ast.Source
+	if err := scanDir(dir, map[string]bool{}, &sources); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", strings.Join(fileExtensions, "/"), dir)
+	}
+	return sources, nil
+}
+
+// scanDir appends a source for each recognized file beneath dir. visited
+// holds the resolved paths of directories already scanned, which both breaks
+// symlink cycles and deduplicates directories reachable via several links.
+func scanDir(dir string, visited map[string]bool, sources *[]*ast.Source) error {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if visited[resolved] {
+		return nil
+	}
+	visited[resolved] = true
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		// Stat rather than the entry's own type so that symlinks are
+		// classified by what they point at.
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			if err := scanDir(path, visited, sources); err != nil {
+				return err
+			}
+		case hasGQLExtension(entry.Name()):
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			*sources = append(*sources, &ast.Source{Name: path, Input: string(contents)})
+		}
+	}
+	return nil
+}
+
+func hasGQLExtension(name string) bool {
+	for _, extension := range fileExtensions {
+		if strings.HasSuffix(name, extension) {
+			return true
+		}
+	}
+	return false
+}
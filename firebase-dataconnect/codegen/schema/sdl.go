@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// SDL renders the schema as GraphQL SDL, including every synthesized type
+// and field. The user-authored sources alone are not enough for tools that
+// validate operations: the per-table query and mutation fields and the _Key
+// and _Data input types only exist after synthesis.
+func (s *Schema) SDL() string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(s.Schema)
+	return buf.String()
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Operations often fail validation because they assume server features the
+// synthesizer does not model yet -- a filter argument, a not-yet-supported
+// field. The raw "Cannot query field" errors are unhelpful then, so unknown
+// field and argument errors are rewritten to suggest the nearest name that
+// does exist.
+
+var (
+	unknownFieldMessage    = regexp.MustCompile(`^Cannot query field "([^"]+)" on type "([^"]+)"`)
+	unknownArgumentMessage = regexp.MustCompile(`^Unknown argument "([^"]+)" on field "([^"]+)\.([^"]+)"`)
+)
+
+// suggestNearestNames appends a "did you mean" hint to unknown-field and
+// unknown-argument errors when a field or argument with a similar name
+// exists.
+func (s *Schema) suggestNearestNames(errs gqlerror.List) gqlerror.List {
+	for _, err := range errs {
+		if match := unknownFieldMessage.FindStringSubmatch(err.Message); match != nil {
+			if def := s.Types[match[2]]; def != nil {
+				var names []string
+				for _, field := range def.Fields {
+					names = append(names, field.Name)
+				}
+				appendSuggestion(err, match[1], names)
+			}
+			continue
+		}
+		if match := unknownArgumentMessage.FindStringSubmatch(err.Message); match != nil {
+			def := s.Types[match[2]]
+			if def == nil {
+				continue
+			}
+			if field := def.Fields.ForName(match[3]); field != nil {
+				var names []string
+				for _, arg := range field.Arguments {
+					names = append(names, arg.Name)
+				}
+				appendSuggestion(err, match[1], names)
+			}
+		}
+	}
+	return errs
+}
+
+func appendSuggestion(err *gqlerror.Error, name string, candidates []string) {
+	if nearest := nearestName(name, candidates); nearest != "" {
+		err.Message += fmt.Sprintf(" (did you mean %q?)", nearest)
+	}
+}
+
+// nearestName returns the candidate closest to name, or "" when every
+// candidate is more than three edits away and a suggestion would be noise.
+func nearestName(name string, candidates []string) string {
+	best := ""
+	bestDistance := 4
+	for _, candidate := range candidates {
+		d := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, min(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
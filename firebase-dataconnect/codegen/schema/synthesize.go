@@ -0,0 +1,435 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gertd/go-pluralize"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// synthPos is attached to synthesized definitions so that validation errors
+// mentioning them point somewhere meaningful.
+var synthPos = &ast.Position{Src: &ast.Source{Name: "(synthesized)"}}
+
+// explainWriter, when set, receives a line for every synthesized type and
+// field, naming the rule that produced it.
+var explainWriter io.Writer
+
+// SetExplainSynthesis directs a trace of schema synthesis to w, answering
+// questions like "why does my operation validate against field X?". Pass nil
+// to disable.
+func SetExplainSynthesis(w io.Writer) {
+	explainWriter = w
+}
+
+func explainf(format string, args ...any) {
+	if explainWriter != nil {
+		fmt.Fprintf(explainWriter, "synthesis: "+format+"\n", args...)
+	}
+}
+
+// synthesizeConnections additionally synthesizes cursor-based connection
+// query fields per table.
+var synthesizeConnections bool
+
+// SetSynthesizeConnections enables per-table connection synthesis: an edge
+// and connection type and a <plural>Connection(first:, after:) query field,
+// for apps paginating with cursors rather than limit/offset.
+func SetSynthesizeConnections(enabled bool) {
+	synthesizeConnections = enabled
+}
+
+// synthesize derives, for each @table type, its key and data input types and
+// its query and mutation fields, mirroring what the Data Connect backend
+// exposes for the deployed schema.
+func (s *Schema) synthesize() error {
+	if synthesizeConnections && s.Types["PageInfo"] == nil {
+		s.Types["PageInfo"] = &ast.Definition{
+			Kind:        ast.Object,
+			Name:        "PageInfo",
+			Description: "Cursor state of one page of a connection.",
+			Fields: ast.FieldList{
+				{Name: "hasNextPage", Type: nonNull(namedType("Boolean")), Position: synthPos},
+				{Name: "endCursor", Type: namedType("String"), Position: synthPos},
+			},
+			Position: synthPos,
+		}
+		explainf("PageInfo: object type [rule: connection synthesis]")
+	}
+	for _, def := range sortedDefinitions(s.Types) {
+		if def.Kind != ast.Object || def.Directives.ForName("table") == nil {
+			continue
+		}
+		s.Tables = append(s.Tables, def)
+		if err := s.synthesizeTable(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedDefinitions returns the map's definitions in declaration order
+// (source name, then line), with the type name as the tiebreaker. Ranging
+// over the map directly would synthesize fields and record tables in a
+// different order on every run, producing spurious diffs in checked-in
+// generated code.
+func sortedDefinitions(types map[string]*ast.Definition) []*ast.Definition {
+	defs := make([]*ast.Definition, 0, len(types))
+	for _, def := range types {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		srcI, lineI := definitionPosition(defs[i])
+		srcJ, lineJ := definitionPosition(defs[j])
+		if srcI != srcJ {
+			return srcI < srcJ
+		}
+		if lineI != lineJ {
+			return lineI < lineJ
+		}
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
+
+func definitionPosition(def *ast.Definition) (src string, line int) {
+	if def.Position == nil {
+		return "", 0
+	}
+	if def.Position.Src != nil {
+		src = def.Position.Src.Name
+	}
+	return src, def.Position.Line
+}
+
+func (s *Schema) synthesizeTable(table *ast.Definition) error {
+	singular, plural := tableNames(table)
+
+	keyType, err := s.synthesizeKeyType(table)
+	if err != nil {
+		return err
+	}
+	dataType := s.synthesizeDataType(table)
+	for _, def := range []*ast.Definition{keyType, dataType} {
+		if existing := s.Types[def.Name]; existing != nil {
+			return fmt.Errorf("%s: cannot synthesize %s: the schema already declares a type with that name",
+				table.Name, def.Name)
+		}
+		s.Types[def.Name] = def
+		explainf("%s: input type %s [rule: input type synthesis]", table.Name, def.Name)
+	}
+
+	explainf("%s: query fields %s(key:) and %s [rule: table reads]", table.Name, singular, plural)
+	query := s.ensureOperationType("Query")
+	query.Fields = append(query.Fields,
+		&ast.FieldDefinition{
+			Name: singular,
+			Arguments: ast.ArgumentDefinitionList{
+				{Name: "key", Type: nonNull(namedType(keyType.Name)), Position: synthPos},
+			},
+			Type:     namedType(table.Name),
+			Position: synthPos,
+		},
+		&ast.FieldDefinition{
+			Name:     plural,
+			Type:     nonNull(listOf(nonNull(namedType(table.Name)))),
+			Position: synthPos,
+		},
+	)
+
+	if synthesizeConnections {
+		if err := s.synthesizeConnection(table, plural); err != nil {
+			return err
+		}
+	}
+
+	explainf("%s: mutation fields %s_insert, %s_insertMany, %s_update, and %s_delete [rule: table CRUD]",
+		table.Name, singular, singular, singular, singular)
+	mutation := s.ensureOperationType("Mutation")
+	mutation.Fields = append(mutation.Fields,
+		&ast.FieldDefinition{
+			Name: singular + "_insert",
+			Arguments: ast.ArgumentDefinitionList{
+				{Name: "data", Type: nonNull(namedType(dataType.Name)), Position: synthPos},
+			},
+			Type:     nonNull(namedType(keyType.Name)),
+			Position: synthPos,
+		},
+		&ast.FieldDefinition{
+			Name: singular + "_insertMany",
+			Arguments: ast.ArgumentDefinitionList{
+				{Name: "data", Type: nonNull(listOf(nonNull(namedType(dataType.Name)))), Position: synthPos},
+			},
+			Type:     nonNull(listOf(nonNull(namedType(keyType.Name)))),
+			Position: synthPos,
+		},
+		&ast.FieldDefinition{
+			Name: singular + "_update",
+			Arguments: ast.ArgumentDefinitionList{
+				{Name: "key", Type: nonNull(namedType(keyType.Name)), Position: synthPos},
+				{Name: "data", Type: nonNull(namedType(dataType.Name)), Position: synthPos},
+			},
+			Type:     namedType(keyType.Name),
+			Position: synthPos,
+		},
+		&ast.FieldDefinition{
+			Name: singular + "_delete",
+			Arguments: ast.ArgumentDefinitionList{
+				{Name: "key", Type: nonNull(namedType(keyType.Name)), Position: synthPos},
+			},
+			Type:     namedType(keyType.Name),
+			Position: synthPos,
+		},
+	)
+	return nil
+}
+
+// synthesizeConnection adds a table's edge and connection types and its
+// cursor-based <plural>Connection query field.
+func (s *Schema) synthesizeConnection(table *ast.Definition, plural string) error {
+	edge := &ast.Definition{
+		Kind:        ast.Object,
+		Name:        table.Name + "_Edge",
+		Description: fmt.Sprintf("One row of a %s connection with its cursor.", table.Name),
+		Fields: ast.FieldList{
+			{Name: "cursor", Type: nonNull(namedType("String")), Position: synthPos},
+			{Name: "node", Type: nonNull(namedType(table.Name)), Position: synthPos},
+		},
+		Position: synthPos,
+	}
+	connection := &ast.Definition{
+		Kind:        ast.Object,
+		Name:        table.Name + "_Connection",
+		Description: fmt.Sprintf("A page of %s rows with cursor state.", table.Name),
+		Fields: ast.FieldList{
+			{Name: "edges", Type: nonNull(listOf(nonNull(namedType(edge.Name)))), Position: synthPos},
+			{Name: "pageInfo", Type: nonNull(namedType("PageInfo")), Position: synthPos},
+		},
+		Position: synthPos,
+	}
+	for _, def := range []*ast.Definition{edge, connection} {
+		if existing := s.Types[def.Name]; existing != nil {
+			return fmt.Errorf("%s: cannot synthesize %s: the schema already declares a type with that name",
+				table.Name, def.Name)
+		}
+		s.Types[def.Name] = def
+		explainf("%s: object type %s [rule: connection synthesis]", table.Name, def.Name)
+	}
+	explainf("%s: query field %sConnection(first:, after:) [rule: connection synthesis]", table.Name, plural)
+	query := s.ensureOperationType("Query")
+	query.Fields = append(query.Fields, &ast.FieldDefinition{
+		Name: plural + "Connection",
+		Arguments: ast.ArgumentDefinitionList{
+			{Name: "first", Type: namedType("Int"), Position: synthPos},
+			{Name: "after", Type: namedType("String"), Position: synthPos},
+		},
+		Type:     nonNull(namedType(connection.Name)),
+		Position: synthPos,
+	})
+	return nil
+}
+
+// KeyFields returns the fields making up a table's primary key: the fields
+// named by @table(key:), or the "id" field by default. Key-class synthesis
+// and the update and delete mutations all depend on the key, so a table
+// without one is rejected with an actionable error.
+func (s *Schema) KeyFields(table *ast.Definition) ([]*ast.FieldDefinition, error) {
+	names := []string{"id"}
+	declared := table.Directives.ForName("table").Arguments.ForName("key")
+	if declared != nil {
+		names = nil
+		for _, child := range declared.Value.Children {
+			names = append(names, child.Value.Raw)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%s: table %s declares an empty @table(key:); name at least one field",
+				position(table.Position), table.Name)
+		}
+	}
+	var fields []*ast.FieldDefinition
+	for _, name := range names {
+		field := table.Fields.ForName(name)
+		if field == nil {
+			if declared == nil {
+				return nil, fmt.Errorf(
+					"%s: table %s has neither an id field nor @table(key:); add one so its key class and update/delete mutations can be generated",
+					position(table.Position), table.Name)
+			}
+			return nil, fmt.Errorf("%s: @table(key:) on %s names %q, which is not a field of the type",
+				position(table.Position), table.Name, name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// synthesizeKeyType builds the <Table>_Key input type from the table's key
+// fields.
+func (s *Schema) synthesizeKeyType(table *ast.Definition) (*ast.Definition, error) {
+	keyFields, err := s.KeyFields(table)
+	if err != nil {
+		return nil, err
+	}
+	key := &ast.Definition{
+		Kind:        ast.InputObject,
+		Name:        table.Name + "_Key",
+		Description: fmt.Sprintf("The key fields identifying a row of the %s table.", table.Name),
+		Position:    synthPos,
+	}
+	for _, field := range keyFields {
+		key.Fields = append(key.Fields, &ast.FieldDefinition{
+			Name:     field.Name,
+			Type:     nonNull(copyType(field.Type)),
+			Position: synthPos,
+		})
+		explainf("%s: input field %s.%s [rule: key field]", table.Name, key.Name, field.Name)
+	}
+	return key, nil
+}
+
+// synthesizeDataType builds the <Table>_Data input type holding the table's
+// settable fields. Fields with a @default directive become nullable, since
+// the server supplies a value when they are omitted; the directive itself is
+// carried onto the input field so that code generation can see it.
+func (s *Schema) synthesizeDataType(table *ast.Definition) *ast.Definition {
+	data := &ast.Definition{
+		Kind:        ast.InputObject,
+		Name:        table.Name + "_Data",
+		Description: fmt.Sprintf("The settable fields of the %s table.", table.Name),
+		Position:    synthPos,
+	}
+	for _, field := range table.Fields {
+		if underlying := s.Types[field.Type.Name()]; underlying != nil && underlying.Kind == ast.Object {
+			explainf("%s: field %s left out of %s [rule: relation fields are not settable]",
+				table.Name, field.Name, data.Name)
+			continue
+		}
+		fieldType := copyType(field.Type)
+		if field.Directives.ForName("default") != nil {
+			fieldType.NonNull = false
+			explainf("%s: input field %s.%s made nullable [rule: @default is server-populated]",
+				table.Name, data.Name, field.Name)
+		}
+		data.Fields = append(data.Fields, &ast.FieldDefinition{
+			Name:       field.Name,
+			Type:       fieldType,
+			Directives: field.Directives,
+			Position:   synthPos,
+		})
+	}
+	return data
+}
+
+// ensureOperationType returns the schema's Query or Mutation type, creating
+// an empty one if the schema does not declare it.
+func (s *Schema) ensureOperationType(name string) *ast.Definition {
+	var slot **ast.Definition
+	switch name {
+	case "Query":
+		slot = &s.Query
+	case "Mutation":
+		slot = &s.Mutation
+	}
+	if *slot == nil {
+		def := &ast.Definition{Kind: ast.Object, Name: name, Position: synthPos}
+		s.Types[name] = def
+		*slot = def
+	}
+	return *slot
+}
+
+// Name inflection shares one pluralize client -- constructing one per call
+// is needlessly expensive with many tables -- and memoizes its results.
+// pluralOverrides is consulted first, for irregular nouns where the
+// inflector's answer is not the one a project wants.
+var (
+	pluralMu        sync.Mutex
+	pluralClient    = pluralize.NewClient()
+	pluralCache     = map[string]string{}
+	pluralOverrides = map[string]string{}
+)
+
+// SetPluralOverrides replaces the irregular-noun overrides consulted before
+// the inflector, e.g. {"person": "people"}.
+func SetPluralOverrides(overrides map[string]string) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+	pluralOverrides = overrides
+	pluralCache = map[string]string{}
+}
+
+// pluralOf returns the plural form of a singular field name.
+func pluralOf(singular string) string {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+	if plural, ok := pluralOverrides[singular]; ok {
+		return plural
+	}
+	if plural, ok := pluralCache[singular]; ok {
+		return plural
+	}
+	plural := pluralClient.Plural(singular)
+	pluralCache[singular] = plural
+	return plural
+}
+
+// tableNames returns the field names used for a table's singular and plural
+// query and mutation fields.
+func tableNames(table *ast.Definition) (singular, plural string) {
+	directive := table.Directives.ForName("table")
+	singular = lowerFirst(table.Name)
+	if arg := directive.Arguments.ForName("singular"); arg != nil {
+		singular = arg.Value.Raw
+	}
+	plural = pluralOf(singular)
+	if arg := directive.Arguments.ForName("plural"); arg != nil {
+		plural = arg.Value.Raw
+	}
+	return singular, plural
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func namedType(name string) *ast.Type {
+	return &ast.Type{NamedType: name, Position: synthPos}
+}
+
+func listOf(elem *ast.Type) *ast.Type {
+	return &ast.Type{Elem: elem, Position: synthPos}
+}
+
+func nonNull(t *ast.Type) *ast.Type {
+	t.NonNull = true
+	return t
+}
+
+func copyType(t *ast.Type) *ast.Type {
+	copied := *t
+	if t.Elem != nil {
+		copied.Elem = copyType(t.Elem)
+	}
+	return &copied
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// validateDefinitions checks schema-level properties that parsing accepts
+// but that synthesis and code generation cannot handle: enums with no values
+// and input types with no fields would flow through to invalid Kotlin, so
+// they are rejected up front with the type's source location.
+func validateDefinitions(s *Schema) error {
+	// Sorted traversal so that which error surfaces first is stable when
+	// several definitions are invalid.
+	for _, def := range sortedDefinitions(s.Types) {
+		if def.BuiltIn || (def.Position != nil && def.Position.Src != nil && def.Position.Src.BuiltIn) {
+			continue
+		}
+		switch def.Kind {
+		case ast.Enum:
+			if len(def.EnumValues) == 0 {
+				return fmt.Errorf("%s: enum %s declares no values", position(def.Position), def.Name)
+			}
+		case ast.InputObject:
+			if len(def.Fields) == 0 {
+				return fmt.Errorf("%s: input type %s declares no fields", position(def.Position), def.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateOperationArguments checks literal argument values in an operation
+// against their declared types. The stock validator misses enum literals in
+// arguments of synthesized fields, so this walk covers them, including
+// literals nested inside list and input-object values.
+func validateOperationArguments(s *Schema, op *ast.OperationDefinition) error {
+	variables := declaredVariables(op)
+	if err := validateOperationDirectives(s, op, variables); err != nil {
+		return err
+	}
+	return walkFields(op.SelectionSet, func(field *ast.Field) error {
+		if field.Definition == nil {
+			return nil
+		}
+		for _, arg := range field.Arguments {
+			argDef := field.Definition.Arguments.ForName(arg.Name)
+			if argDef == nil {
+				continue
+			}
+			if err := validateValue(s, arg.Value, argDef.Type, variables); err != nil {
+				return fmt.Errorf("%s: argument %q of field %q: %w",
+					position(arg.Value.Position), arg.Name, field.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// validateOperationDirectives checks the directives applied to an operation
+// against the prelude's directive definitions: the directive must exist, be
+// allowed on this kind of operation, and be given well-typed arguments.
+func validateOperationDirectives(s *Schema, op *ast.OperationDefinition,
+	variables map[string]*ast.VariableDefinition) error {
+	location := ast.LocationQuery
+	if op.Operation == ast.Mutation {
+		location = ast.LocationMutation
+	}
+	for _, directive := range op.Directives {
+		def := s.Directives[directive.Name]
+		if def == nil {
+			return fmt.Errorf("%s: operation %s: unknown directive @%s",
+				position(directive.Position), op.Name, directive.Name)
+		}
+		allowed := false
+		for _, candidate := range def.Locations {
+			if candidate == location {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%s: operation %s: directive @%s cannot be applied to a %s",
+				position(directive.Position), op.Name, directive.Name, op.Operation)
+		}
+		for _, arg := range directive.Arguments {
+			argDef := def.Arguments.ForName(arg.Name)
+			if argDef == nil {
+				return fmt.Errorf("%s: operation %s: @%s has no argument %q",
+					position(arg.Position), op.Name, directive.Name, arg.Name)
+			}
+			if err := validateValue(s, arg.Value, argDef.Type, variables); err != nil {
+				return fmt.Errorf("%s: operation %s: argument %q of @%s: %w",
+					position(arg.Value.Position), op.Name, arg.Name, directive.Name, err)
+			}
+		}
+		for _, argDef := range def.Arguments {
+			if argDef.Type.NonNull && argDef.DefaultValue == nil &&
+				directive.Arguments.ForName(argDef.Name) == nil {
+				return fmt.Errorf("%s: operation %s: @%s is missing required argument %q",
+					position(directive.Position), op.Name, directive.Name, argDef.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// declaredVariables indexes an operation's variable declarations by name.
+func declaredVariables(op *ast.OperationDefinition) map[string]*ast.VariableDefinition {
+	variables := map[string]*ast.VariableDefinition{}
+	for _, variable := range op.VariableDefinitions {
+		variables[variable.Variable] = variable
+	}
+	return variables
+}
+
+// validateValue checks a literal value against the type it is supplied for.
+// Variable usages are checked against their declarations, including usages
+// nested inside list and input-object values, which the stock validator
+// does not reach.
+func validateValue(s *Schema, value *ast.Value, t *ast.Type,
+	variables map[string]*ast.VariableDefinition) error {
+	switch value.Kind {
+	case ast.Variable:
+		declaration := variables[value.Raw]
+		if declaration == nil {
+			return fmt.Errorf("variable $%s is not declared by the operation", value.Raw)
+		}
+		if !assignableType(declaration.Type, t, declaration.DefaultValue != nil) {
+			return fmt.Errorf("variable $%s of type %s cannot be used where %s is expected",
+				value.Raw, declaration.Type.String(), t.String())
+		}
+		return nil
+	case ast.ListValue:
+		elemType := t
+		if t.Elem != nil {
+			elemType = t.Elem
+		}
+		for _, child := range value.Children {
+			if err := validateValue(s, child.Value, elemType, variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ast.ObjectValue:
+		def := s.Types[t.Name()]
+		if def == nil || def.Kind != ast.InputObject {
+			return nil
+		}
+		for _, child := range value.Children {
+			fieldDef := def.Fields.ForName(child.Name)
+			if fieldDef == nil {
+				return fmt.Errorf("%s is not a field of input type %s", child.Name, def.Name)
+			}
+			if err := validateValue(s, child.Value, fieldDef.Type, variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ast.EnumValue:
+		def := s.Types[t.Name()]
+		if def == nil || def.Kind != ast.Enum {
+			return fmt.Errorf("enum literal %s supplied for non-enum type %s", value.Raw, t.String())
+		}
+		if def.EnumValues.ForName(value.Raw) == nil {
+			var allowed []string
+			for _, enumValue := range def.EnumValues {
+				allowed = append(allowed, enumValue.Name)
+			}
+			return fmt.Errorf("%s is not a value of enum %s; expected one of %s",
+				value.Raw, def.Name, strings.Join(allowed, ", "))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// assignableType reports whether a variable declared with the given type can
+// be used where expected is required. hasDefault relaxes the non-null
+// requirement, since a defaulted variable always has a value.
+func assignableType(declared, expected *ast.Type, hasDefault bool) bool {
+	if expected.NonNull && !declared.NonNull && !hasDefault {
+		return false
+	}
+	if (declared.Elem == nil) != (expected.Elem == nil) {
+		return false
+	}
+	if declared.Elem != nil {
+		return assignableType(declared.Elem, expected.Elem, false)
+	}
+	return declared.NamedType == expected.NamedType
+}
+
+// walkFields applies f to every field selection, depth first.
+func walkFields(selections ast.SelectionSet, f func(field *ast.Field) error) error {
+	for _, selection := range selections {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+		if err := f(field); err != nil {
+			return err
+		}
+		if err := walkFields(field.SelectionSet, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// position formats an ast position for error messages.
+func position(pos *ast.Position) string {
+	if pos == nil || pos.Src == nil {
+		return "(unknown location)"
+	}
+	return fmt.Sprintf("%s:%d", pos.Src.Name, pos.Line)
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const enumSchemaGQL = `
+enum PostStatus {
+  DRAFT
+  PUBLISHED
+}
+
+type Post @table {
+  id: UUID!
+  title: String!
+  status: PostStatus!
+}
+
+extend type Query {
+  postsByStatus(status: PostStatus!): [Post!]!
+}
+`
+
+func loadTestSchema(t *testing.T, gql string) *Schema {
+	t.Helper()
+	s, err := load([]*ast.Source{{Name: "schema.gql", Input: gql}})
+	if err != nil {
+		t.Fatalf("load() failed: %v", err)
+	}
+	return s
+}
+
+func loadTestOperations(t *testing.T, s *Schema, gql string) ([]*Operation, error) {
+	t.Helper()
+	return loadOperations(s, []*ast.Source{{Name: "operations.gql", Input: gql}})
+}
+
+func TestEnumLiteralArgumentValid(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	operations, err := loadTestOperations(t, s, `
+		query PublishedPosts {
+			postsByStatus(status: PUBLISHED) { id title }
+		}
+	`)
+	if err != nil {
+		t.Fatalf("loadOperations() failed: %v", err)
+	}
+	if len(operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(operations))
+	}
+}
+
+func TestEnumLiteralArgumentUnknownValue(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	_, err := loadTestOperations(t, s, `
+		query ArchivedPosts {
+			postsByStatus(status: ARCHIVED) { id }
+		}
+	`)
+	if err == nil {
+		t.Fatal("loadOperations() succeeded, want enum value error")
+	}
+	if !strings.Contains(err.Error(), "ARCHIVED") {
+		t.Errorf("error %q does not mention the offending value", err)
+	}
+}
+
+func TestVariableUsageNestedInInputObjectArgument(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL+`
+		input PostFilter {
+			status: PostStatus
+			titlePrefix: String
+		}
+
+		extend type Query {
+			filteredPosts(filter: PostFilter!): [Post!]!
+		}
+	`)
+	if _, err := loadTestOperations(t, s, `
+		query FilteredPosts($status: PostStatus) {
+			filteredPosts(filter: { status: $status }) { id }
+		}
+	`); err != nil {
+		t.Fatalf("loadOperations() failed for a valid nested variable usage: %v", err)
+	}
+
+	_, err := loadTestOperations(t, s, `
+		query BadlyFilteredPosts($status: String) {
+			filteredPosts(filter: { status: $status }) { id }
+		}
+	`)
+	if err == nil {
+		t.Fatal("loadOperations() succeeded, want variable type error")
+	}
+	if !strings.Contains(err.Error(), "$status") {
+		t.Errorf("error %q does not mention the offending variable", err)
+	}
+}
+
+func TestOperationDirectiveValidation(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	if _, err := loadTestOperations(t, s, `
+		query PublishedPosts @auth(level: USER) {
+			postsByStatus(status: PUBLISHED) { id }
+		}
+	`); err != nil {
+		t.Fatalf("loadOperations() failed for a valid @auth directive: %v", err)
+	}
+
+	for name, operation := range map[string]string{
+		"unknown directive": `query Q1 @bogus { postsByStatus(status: PUBLISHED) { id } }`,
+		"unknown argument":  `query Q2 @auth(bogus: true) { postsByStatus(status: PUBLISHED) { id } }`,
+		"bad enum value":    `query Q3 @auth(level: SOMETIMES) { postsByStatus(status: PUBLISHED) { id } }`,
+	} {
+		if _, err := loadTestOperations(t, s, operation); err == nil {
+			t.Errorf("%s: loadOperations() succeeded, want error", name)
+		}
+	}
+}
+
+func TestOperationCommentPreserved(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	operations, err := loadTestOperations(t, s, `
+# Lists every published post.
+# Ordered by the backend's default ordering.
+query PublishedPosts {
+  postsByStatus(status: PUBLISHED) { id }
+}
+`)
+	if err != nil {
+		t.Fatalf("loadOperations() failed: %v", err)
+	}
+	want := "Lists every published post.\nOrdered by the backend's default ordering."
+	if operations[0].Doc != want {
+		t.Errorf("Doc = %q, want %q", operations[0].Doc, want)
+	}
+}
+
+func TestVariableUsageUndeclared(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	_, err := loadTestOperations(t, s, `
+		query PostsByStatus {
+			postsByStatus(status: $status) { id }
+		}
+	`)
+	if err == nil {
+		t.Fatal("loadOperations() succeeded, want undeclared variable error")
+	}
+}
+
+func TestUnknownFieldSuggestsNearestName(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL)
+	_, err := loadTestOperations(t, s, `
+		query MisspelledPosts {
+			postsByStatuss(status: PUBLISHED) { id }
+		}
+	`)
+	if err == nil {
+		t.Fatal("loadOperations() succeeded, want unknown field error")
+	}
+	if !strings.Contains(err.Error(), `did you mean "postsByStatus"`) {
+		t.Errorf("error %q does not suggest the nearest field", err)
+	}
+}
+
+func TestSchemaVersionPragma(t *testing.T) {
+	_, err := load([]*ast.Source{{Name: "schema.gql", Input: "# dataconnect: schema-version=9\n" + enumSchemaGQL}})
+	if err == nil {
+		t.Fatal("load() succeeded with an unsupported schema-version")
+	}
+	if !strings.Contains(err.Error(), `"9"`) {
+		t.Errorf("error %q does not mention the offending version", err)
+	}
+
+	// @redacted only exists from the v2 prelude on, so a schema pinned to
+	// version 1 must not see it.
+	redactedGQL := `
+		type Account @table {
+			id: UUID!
+			ssn: String! @redacted
+		}
+	`
+	if _, err := load([]*ast.Source{{Name: "schema.gql", Input: redactedGQL}}); err != nil {
+		t.Fatalf("load() failed without a version pragma: %v", err)
+	}
+	if _, err := load([]*ast.Source{{Name: "schema.gql",
+		Input: "# dataconnect: schema-version=1\n" + redactedGQL}}); err == nil {
+		t.Error("load() succeeded using @redacted under schema-version=1")
+	}
+}
+
+func TestEnumLiteralNestedInListArgument(t *testing.T) {
+	s := loadTestSchema(t, enumSchemaGQL+`
+		extend type Query {
+			postsByStatuses(statuses: [PostStatus!]!): [Post!]!
+		}
+	`)
+	_, err := loadTestOperations(t, s, `
+		query SomePosts {
+			postsByStatuses(statuses: [PUBLISHED, BOGUS]) { id }
+		}
+	`)
+	if err == nil {
+		t.Fatal("loadOperations() succeeded, want enum value error")
+	}
+	if !strings.Contains(err.Error(), "BOGUS") {
+		t.Errorf("error %q does not mention the offending value", err)
+	}
+}
+
+// TestSynthesisOrderIsDeterministic loads a multi-table schema repeatedly
+// and requires tables and synthesized query fields in declaration order
+// every time. Traversing the type map directly would reorder them across
+// runs and dirty checked-in generated code.
+func TestSynthesisOrderIsDeterministic(t *testing.T) {
+	const gql = `
+type Zebra @table { id: UUID! }
+type Alpha @table { id: UUID! }
+type Mango @table { id: UUID! }
+`
+	wantTables := []string{"Zebra", "Alpha", "Mango"}
+	var wantFields []string
+	for run := 0; run < 5; run++ {
+		s := loadTestSchema(t, gql)
+		var tables []string
+		for _, table := range s.Tables {
+			tables = append(tables, table.Name)
+		}
+		if !reflect.DeepEqual(tables, wantTables) {
+			t.Fatalf("run %d: tables are %v, want %v", run, tables, wantTables)
+		}
+		var fields []string
+		for _, field := range s.Types["Query"].Fields {
+			fields = append(fields, field.Name)
+		}
+		if run == 0 {
+			wantFields = fields
+			continue
+		}
+		if !reflect.DeepEqual(fields, wantFields) {
+			t.Fatalf("run %d: query fields are %v, want %v", run, fields, wantFields)
+		}
+	}
+}
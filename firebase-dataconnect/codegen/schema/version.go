@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Schema files may declare which Data Connect schema semantics they are
+// written against with a comment pragma, usually on the first line:
+//
+//	# dataconnect: schema-version=2
+//
+// The declared version selects the matching prelude, so older schemas keep
+// loading with their original semantics as the default prelude moves on.
+
+var versionPragma = regexp.MustCompile(`(?m)^#\s*dataconnect:\s*schema-version\s*=\s*(\S+)\s*$`)
+
+// schemaVersionPreludes maps each supported schema version to the prelude it
+// loads against.
+var schemaVersionPreludes = map[string]string{
+	"1": "v1",
+	"2": "v2",
+}
+
+// declaredSchemaVersion scans the schema sources for version pragmas. It
+// returns "" when no source declares one, and an error for an unsupported
+// version or for sources disagreeing with each other.
+func declaredSchemaVersion(sources []*ast.Source) (string, error) {
+	version := ""
+	declaredBy := ""
+	for _, source := range sources {
+		match := versionPragma.FindStringSubmatch(source.Input)
+		if match == nil {
+			continue
+		}
+		declared := match[1]
+		if _, ok := schemaVersionPreludes[declared]; !ok {
+			return "", fmt.Errorf("%s: unsupported schema-version %q: this codegen supports %s",
+				source.Name, declared, strings.Join(supportedSchemaVersions(), ", "))
+		}
+		if version != "" && version != declared {
+			return "", fmt.Errorf("%s declares schema-version=%s but %s declares schema-version=%s",
+				declaredBy, version, source.Name, declared)
+		}
+		version = declared
+		declaredBy = source.Name
+	}
+	return version, nil
+}
+
+func supportedSchemaVersions() []string {
+	var versions []string
+	for version := range schemaVersionPreludes {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
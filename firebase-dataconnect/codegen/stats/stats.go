@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats collects named wall-time measurements from across the
+// codegen packages, so users with slow builds can tell which phase to
+// optimize. Collection is cheap and always on; the measurements are only
+// reported when -stats_file is set.
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Timing is one named measurement, in the order it finished.
+type Timing struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+var (
+	mu      sync.Mutex
+	timings []Timing
+)
+
+// Time starts measuring a named phase and returns the function that stops
+// the measurement and records it:
+//
+//	defer stats.Time("schema parse")()
+func Time(name string) func() {
+	start := time.Now()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		timings = append(timings, Timing{Name: name, Seconds: time.Since(start).Seconds()})
+	}
+}
+
+// All returns the measurements recorded so far.
+func All() []Timing {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Timing(nil), timings...)
+}
+
+// WriteFile writes the recorded measurements as JSON to the given file.
+func WriteFile(path string) error {
+	contents, err := json.MarshalIndent(All(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o666)
+}
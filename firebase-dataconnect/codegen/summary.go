@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// summary aggregates what a run did, for the optional end-of-run report.
+type summary struct {
+	Operations   int           `json:"operations"`
+	FilesWritten int           `json:"filesWritten"`
+	FilesSkipped int           `json:"filesSkipped"`
+	FilesPruned  int           `json:"filesPruned"`
+	Warnings     int           `json:"warnings"`
+	Errors       int           `json:"errors"`
+	Phases       []phaseTiming `json:"phases"`
+}
+
+// phaseTiming is the wall time one phase of the run took.
+type phaseTiming struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// phase records the wall time of one phase, measured from start to now.
+func (s *summary) phase(name string, start time.Time) {
+	s.Phases = append(s.Phases, phaseTiming{Name: name, Seconds: time.Since(start).Seconds()})
+}
+
+// print writes the human-readable report.
+func (s *summary) print(w io.Writer) {
+	fmt.Fprintf(w, "operations: %d; files written: %d, skipped: %d, pruned: %d; warnings: %d, errors: %d\n",
+		s.Operations, s.FilesWritten, s.FilesSkipped, s.FilesPruned, s.Warnings, s.Errors)
+	for _, phase := range s.Phases {
+		fmt.Fprintf(w, "  %-16s %.3fs\n", phase.Name, phase.Seconds)
+	}
+}
+
+// writeJSON writes the report as JSON to the given file.
+func (s *summary) writeJSON(path string) error {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o666)
+}
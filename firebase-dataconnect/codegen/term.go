@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "os"
+
+// colors renders ANSI-colored output when enabled. Color is used only for
+// human-facing diagnostics on stderr and never written into generated files.
+type colors struct {
+	enabled bool
+}
+
+// newColors enables color when stderr is a terminal, unless suppressed by
+// the -no_color flag or the NO_COLOR convention.
+func newColors(noColorFlag bool) colors {
+	if noColorFlag || os.Getenv("NO_COLOR") != "" {
+		return colors{}
+	}
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return colors{}
+	}
+	return colors{enabled: true}
+}
+
+func (c colors) wrap(code, s string) string {
+	if !c.enabled {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+func (c colors) red(s string) string    { return c.wrap("31", s) }
+func (c colors) yellow(s string) string { return c.wrap("33", s) }
+func (c colors) bold(s string) string   { return c.wrap("1", s) }